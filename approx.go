@@ -0,0 +1,52 @@
+package erlangc
+
+import "math"
+
+// getHalfinWhitt approximates Erlang C probability of wait for the quality-of-service parameter beta
+// with the normal distribution, P(wait) = 1 / (1 + beta * Phi(beta) / phi(beta))
+func getHalfinWhitt(beta float64) float64 {
+	if beta <= 0 {
+		return 1
+	}
+	cdf := 0.5 * math.Erfc(-beta/math.Sqrt2)
+	pdf := math.Exp(-beta*beta/2) / math.Sqrt(2*math.Pi)
+	return 1 / (1 + beta*cdf/pdf)
+}
+
+func getErlangCApprox(intensity float64, agents int64) float64 {
+	return getHalfinWhitt((float64(agents) - intensity) / math.Sqrt(intensity))
+}
+
+// HalfinWhittBeta returns quality-of-service parameter of square-root staffing
+// for the target probability of wait (0 < waitProbability < 1)
+func HalfinWhittBeta(waitProbability float64) float64 {
+	low, high := 0.0, 10.0
+	for i := 0; i < 100; i++ {
+		beta := (low + high) / 2
+		if getHalfinWhitt(beta) > waitProbability {
+			low = beta
+		} else {
+			high = beta
+		}
+	}
+	return high
+}
+
+// SquareRootStaffing returns number of agents intensity + beta * sqrt(intensity),
+// where quality-of-service parameter beta trades service for efficiency, see HalfinWhittBeta
+func SquareRootStaffing(intensity float64, beta float64) int64 {
+	return int64(math.Ceil(intensity + beta*math.Sqrt(intensity)))
+}
+
+type approxErlangC struct {
+	intensity float64
+	agents    int64
+}
+
+func (e *approxErlangC) erlangC() float64 {
+	return getErlangCApprox(e.intensity, e.agents)
+}
+
+func (e *approxErlangC) next() {
+	e.agents++
+}
@@ -0,0 +1,66 @@
+package erlangc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHalfinWhittBeta(t *testing.T) {
+	beta := HalfinWhittBeta(0.2)
+	if res := getHalfinWhitt(beta); math.Abs(res-0.2) > 1e-9 {
+		t.Errorf("probability of wait of beta %f should be 0.2, got %f", beta, res)
+	}
+
+	agents := SquareRootStaffing(100, beta)
+	expected := int64(math.Ceil(100 + beta*10))
+	if agents != expected {
+		t.Errorf("square root staffing should be %d, got %d", expected, agents)
+	}
+	// P(wait) of square root staffing stays close to the target for any intensity
+	for _, intensity := range []float64{100, 1000} {
+		agents := SquareRootStaffing(intensity, beta)
+		res := getErlangCFloat(intensity, agents)
+		if math.Abs(res-0.2) > 0.03 {
+			t.Errorf("probability of wait of %d agents for %f erlangs should be about 0.2, got %f", agents, intensity, res)
+		}
+	}
+}
+
+func TestEngineApproxError(t *testing.T) {
+	maxError := 0.0
+	for _, intensity := range []float64{8, 33.33, 100, 500} {
+		for agents := int64(intensity) + 1; float64(agents) < intensity+3*math.Sqrt(intensity); agents++ {
+			res := getServiceLevel(getErlangCApprox(intensity, agents), intensity, agents, 60, 300)
+			exact := getFullServiceLevel(intensity, agents, 60, 300)
+			maxError = math.Max(maxError, math.Abs(res-exact))
+		}
+	}
+	t.Logf("maximum service level error of the approximation is %f", maxError)
+	if maxError > 0.05 {
+		t.Errorf("service level error should be under 0.05, got %f", maxError)
+	}
+
+	params := readFteParams(t)
+	maxDiff := int64(0)
+	for i := range params {
+		params[i].Engine = EngineExact
+		exact := GetNumberOfAgents(params[i])
+		params[i].Engine = EngineApprox
+		res := GetNumberOfAgents(params[i])
+		diff := res.Volume - exact.Volume
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > maxDiff {
+			maxDiff = diff
+		}
+	}
+	t.Logf("maximum agents error of the approximation on fteParams.json is %d", maxDiff)
+	if maxDiff > 2 {
+		t.Errorf("agents error should be at most 2, got %d", maxDiff)
+	}
+}
+
+func BenchmarkGetNumberOfAgentsApprox5000(b *testing.B) {
+	benchmarkGetNumberOfAgents(b, EngineApprox, 5000)
+}
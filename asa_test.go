@@ -0,0 +1,43 @@
+package erlangc
+
+import "testing"
+
+func TestGetNumberOfAgentsTargetAsa(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Index:              0,
+		Volume:             100,
+		IntervalLength:     900,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+	}
+	sl := GetNumberOfAgents(params)
+
+	params.TargetAsa = 5
+	both := GetNumberOfAgents(params)
+	if both.Constraint != ConstraintAsa || both.Asa > 5 || both.Volume <= sl.Volume {
+		t.Errorf("ASA of 5 seconds should need more than %d agents, got %+v", sl.Volume, both)
+	}
+	kpis := GetKpis(KpiParams{FteParams: params, Agents: both.Volume - 1})
+	if kpis.Asa <= 5 {
+		t.Errorf("ASA of %d agents should be above 5 seconds, got %f", both.Volume-1, kpis.Asa)
+	}
+
+	params.TargetServiceLevel = 0
+	params.TargetAsa = 20
+	asa := GetNumberOfAgents(params)
+	if asa.Constraint != ConstraintAsa || asa.Asa > 20 {
+		t.Errorf("ASA of 20 seconds should be binding, got %+v", asa)
+	}
+	kpis = GetKpis(KpiParams{FteParams: params, Agents: asa.Volume - 1})
+	if kpis.Asa <= 20 {
+		t.Errorf("ASA of %d agents should be above 20 seconds, got %f", asa.Volume-1, kpis.Asa)
+	}
+
+	params.Patience = 60
+	abandon := GetNumberOfAgents(params)
+	if abandon.Asa > 20 || abandon.Volume > asa.Volume {
+		t.Errorf("ASA of 20 seconds with abandonment should need at most %d agents, got %+v", asa.Volume, abandon)
+	}
+}
@@ -0,0 +1,159 @@
+package erlangc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+)
+
+// backlogEpsilon - items below it are considered handled
+const backlogEpsilon = 1e-9
+
+// BacklogResult - staffing of an interval of deferred work with unfinished work carried over
+//
+// Arrivals - items arriving in the interval, the Volume of the params
+// Handled - items handled in the interval, carried over and new
+// Backlog - items left at the end of the interval and carried into the next one
+// Overdue - items of the backlog that are past the target response time
+type BacklogResult struct {
+	FteResult
+	Arrivals float64
+	Handled  float64
+	Backlog  float64
+	Overdue  float64
+}
+
+// backlogCohort - items that arrived in the same interval
+type backlogCohort struct {
+	items    float64
+	aht      float64
+	deadline float64
+}
+
+// PlanBacklog plans deferred work (email, back office) where unfinished work of an interval is carried into
+// the next one. Intervals of every ID are walked in Index order and are expected to follow each other without gaps.
+// Items arriving in an interval have to be handled within targetTime from its start, or within the interval when
+// targetTime is 0. Every interval gets the least number of agents that, working at the same rate, handles all
+// carried over and arrived items before their deadlines. The rounded headcount determines the items actually
+// handled, the rest is carried over. Results keep the order of params, an opening backlog can be given as volume
+// of the first interval.
+//
+// Params are validated first, on invalid rows no results are returned and the rows are reported as *RowError.
+func PlanBacklog(params []FteParams) ([]BacklogResult, error) {
+	return defaultCalculator.PlanBacklog(params)
+}
+
+func (c *Calculator) PlanBacklog(params []FteParams) ([]BacklogResult, error) {
+	errs := make([]error, len(params))
+	finished := make([]bool, len(params))
+	failed := false
+	rows := make(map[string][]int)
+	var ids []string
+	for i, param := range params {
+		finished[i] = true
+		if errs[i] = c.Validate(param); errs[i] != nil {
+			failed = true
+		}
+		if _, ok := rows[param.ID]; !ok {
+			ids = append(ids, param.ID)
+		}
+		rows[param.ID] = append(rows[param.ID], i)
+	}
+	for _, id := range ids {
+		sort.SliceStable(rows[id], func(i, j int) bool {
+			return params[rows[id][i]].Index < params[rows[id][j]].Index
+		})
+		for k := 1; k < len(rows[id]); k++ {
+			row := rows[id][k]
+			if params[row].Index == params[rows[id][k-1]].Index && errs[row] == nil {
+				errs[row] = errors.New("duplicate index")
+				failed = true
+			}
+		}
+	}
+	if failed {
+		return nil, c.getBatchError(context.Background(), params, finished, errs)
+	}
+
+	results := make([]BacklogResult, len(params))
+	for _, id := range ids {
+		c.planBacklog(params, rows[id], results)
+	}
+	return results, nil
+}
+
+// planBacklog plans intervals of a single ID, rows are indexes of params in the order of intervals
+func (c *Calculator) planBacklog(params []FteParams, rows []int, results []BacklogResult) {
+	// cohorts are ordered by deadline, seconds since the start of the first interval
+	var cohorts []backlogCohort
+	start := 0.0
+	for _, row := range rows {
+		fteParams := params[row]
+		fteParams.Concurrency = 0
+		length := float64(fteParams.IntervalLength)
+		end := start + length
+
+		if fteParams.Volume > 0 {
+			deadline := end
+			if fteParams.TargetTime > 0 {
+				deadline = start + float64(fteParams.TargetTime)
+			}
+			cohort := backlogCohort{items: fteParams.Volume, aht: float64(fteParams.Aht), deadline: deadline}
+			i := sort.Search(len(cohorts), func(i int) bool { return cohorts[i].deadline > deadline })
+			cohorts = append(cohorts, backlogCohort{})
+			copy(cohorts[i+1:], cohorts[i:])
+			cohorts[i] = cohort
+		}
+
+		// least constant rate meeting every deadline, overdue work has to be handled within the interval
+		agents := 0.0
+		work := 0.0
+		for _, cohort := range cohorts {
+			work += cohort.items * cohort.aht
+			available := cohort.deadline - start
+			if available <= 0 {
+				available = length
+			}
+			agents = math.Max(agents, work/available)
+		}
+
+		// the scheduled headcount after shrinkage handles work, up to the max occupancy
+		result, _ := c.getFteResult(fteParams, agents, agents, ConstraintWorkload)
+		capacity := float64(result.Volume) * (1 - fteParams.Shrinkage) * length
+		if fteParams.MaxOccupancy > 0 {
+			capacity *= fteParams.MaxOccupancy
+		}
+
+		handled := 0.0
+		backlog := 0.0
+		overdue := 0.0
+		remaining := cohorts[:0]
+		for _, cohort := range cohorts {
+			if capacity > 0 && cohort.aht > 0 {
+				items := math.Min(cohort.items, capacity/cohort.aht)
+				cohort.items -= items
+				capacity -= items * cohort.aht
+				handled += items
+			}
+			if cohort.items <= backlogEpsilon {
+				continue
+			}
+			backlog += cohort.items
+			if cohort.deadline <= end {
+				overdue += cohort.items
+			}
+			remaining = append(remaining, cohort)
+		}
+		cohorts = remaining
+
+		results[row] = BacklogResult{
+			FteResult: result,
+			Arrivals:  fteParams.Volume,
+			Handled:   handled,
+			Backlog:   backlog,
+			Overdue:   overdue,
+		}
+		start = end
+	}
+}
@@ -0,0 +1,92 @@
+package erlangc
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPlanBacklog(t *testing.T) {
+	base := FteParams{ID: "1", IntervalLength: 3600, Aht: 600, Channel: "email"}
+
+	// without target time every interval has to clear its own work, like the workload model
+	var params []FteParams
+	for i, volume := range []float64{30, 0, 12} {
+		param := base
+		param.Index = int64(i)
+		param.Volume = volume
+		param.MaxOccupancy = 0.8
+		param.Shrinkage = 0.2
+		params = append(params, param)
+	}
+	results, err := PlanBacklog(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, result := range results {
+		expected := GetNumberOfAgentsWorkload(params[i]).Volume
+		if result.Volume != expected || result.Backlog != 0 {
+			t.Errorf("interval %d should have %d agents and no backlog, got %+v", i, expected, result)
+		}
+	}
+
+	// a burst of 240 items in a day is worked off at 2 agents handling 12 items an hour
+	params = nil
+	for i := 0; i < 24; i++ {
+		param := base
+		param.Index = int64(i)
+		param.TargetTime = 86400
+		params = append(params, param)
+	}
+	params[0].Volume = 240
+	// results keep the order of params
+	params[0], params[5] = params[5], params[0]
+	results, err = PlanBacklog(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[5].Index != 0 || results[5].Backlog != 228 || results[5].Arrivals != 240 {
+		t.Errorf("first interval should carry 228 items over, got %+v", results[5])
+	}
+	handled := 0.0
+	for _, result := range results {
+		handled += result.Handled
+		if result.Volume > 2 || result.Overdue != 0 {
+			t.Errorf("interval %d should have at most 2 agents and nothing overdue, got %+v", result.Index, result)
+		}
+	}
+	if math.Abs(handled-240) > 1e-9 {
+		t.Errorf("all 240 items should be handled, got %f", handled)
+	}
+
+	// rounding down leaves work overdue, which is carried over
+	params = []FteParams{base, base}
+	params[0].Volume = 10
+	params[1].Index = 1
+	results, err = NewCalculator(WithRounding(RoundingFloor)).PlanBacklog(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Volume != 1 || results[0].Handled != 6 || results[0].Overdue != 4 || results[1].Backlog != 4 {
+		t.Errorf("1 agent should leave 4 items overdue, got %+v", results)
+	}
+}
+
+func TestPlanBacklogInvalid(t *testing.T) {
+	params := []FteParams{
+		{ID: "1", Index: 0, Volume: 10, IntervalLength: 3600, Aht: 600},
+		{ID: "1", Index: 0, Volume: 10, IntervalLength: 3600, Aht: 600},
+		{ID: "2", Index: 0, Volume: 10, IntervalLength: 0, Aht: 600},
+	}
+	results, err := PlanBacklog(params)
+	if results != nil {
+		t.Errorf("invalid params should have no results, got %+v", results)
+	}
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || rowErr.Row != 1 {
+		t.Errorf("duplicate index should be reported as row 1, got %v", err)
+	}
+	if len(err.(interface{ Unwrap() []error }).Unwrap()) != 2 {
+		t.Errorf("both invalid rows should be reported, got %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package erlangc
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	big "github.com/Tymeshift/erlang-c-go/internal/bignum"
+)
+
+// DefaultFactorialCacheSize - number of factorials kept by the default cache
+const DefaultFactorialCacheSize = 4096
+
+// CacheStats - usage of a factorial cache
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Size   int
+}
+
+// FactorialCache - storage of factorials used by the exact engine, implementations must be safe for concurrent use
+type FactorialCache interface {
+	Get(n int64) (*big.Int, bool)
+	Add(n int64, fact *big.Int)
+	Clear()
+	Stats() CacheStats
+}
+
+type lruEntry struct {
+	n    int64
+	fact *big.Int
+}
+
+type lruFactorialCache struct {
+	mutex  sync.Mutex
+	size   int
+	items  map[int64]*list.Element
+	order  *list.List
+	hits   uint64
+	misses uint64
+}
+
+// NewLRUFactorialCache returns a cache keeping at most size most recently used factorials.
+// The search for agents reads factorials of 0 to the number of agents, so size should exceed the largest number of agents.
+func NewLRUFactorialCache(size int) FactorialCache {
+	return &lruFactorialCache{
+		size:  size,
+		items: make(map[int64]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (c *lruFactorialCache) Get(n int64) (*big.Int, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	item, ok := c.items[n]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(item)
+	return item.Value.(*lruEntry).fact, true
+}
+
+func (c *lruFactorialCache) Add(n int64, fact *big.Int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if item, ok := c.items[n]; ok {
+		item.Value.(*lruEntry).fact = fact
+		c.order.MoveToFront(item)
+		return
+	}
+	if c.size <= 0 {
+		return
+	}
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).n)
+	}
+	c.items[n] = c.order.PushFront(&lruEntry{n: n, fact: fact})
+}
+
+func (c *lruFactorialCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.items = make(map[int64]*list.Element)
+	c.order.Init()
+	c.hits = 0
+	c.misses = 0
+}
+
+func (c *lruFactorialCache) Stats() CacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Size: c.order.Len()}
+}
+
+type noopFactorialCache struct {
+	misses atomic.Uint64
+}
+
+// NewNoopFactorialCache returns a cache that keeps nothing, every factorial is calculated again
+func NewNoopFactorialCache() FactorialCache {
+	return &noopFactorialCache{}
+}
+
+func (c *noopFactorialCache) Get(n int64) (*big.Int, bool) {
+	c.misses.Add(1)
+	return nil, false
+}
+
+func (c *noopFactorialCache) Add(n int64, fact *big.Int) {}
+
+func (c *noopFactorialCache) Clear() {
+	c.misses.Store(0)
+}
+
+func (c *noopFactorialCache) Stats() CacheStats {
+	return CacheStats{Misses: c.misses.Load()}
+}
+
+type tableFactorialCache struct {
+	table  []*big.Int
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewTableFactorialCache returns a read-only cache with precomputed factorials of 0 to max,
+// larger factorials are calculated on every use
+func NewTableFactorialCache(max int64) FactorialCache {
+	table := make([]*big.Int, max+1)
+	fact := big.NewInt(1)
+	for n := int64(0); n <= max; n++ {
+		if n > 0 {
+			fact = new(big.Int).Mul(fact, big.NewInt(n))
+		}
+		table[n] = fact
+	}
+	return &tableFactorialCache{table: table}
+}
+
+func (c *tableFactorialCache) Get(n int64) (*big.Int, bool) {
+	if n < 0 || n >= int64(len(c.table)) {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	return c.table[n], true
+}
+
+func (c *tableFactorialCache) Add(n int64, fact *big.Int) {}
+
+func (c *tableFactorialCache) Clear() {
+	c.hits.Store(0)
+	c.misses.Store(0)
+}
+
+func (c *tableFactorialCache) Stats() CacheStats {
+	return CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load(), Size: len(c.table)}
+}
@@ -0,0 +1,103 @@
+package erlangc
+
+import (
+	"testing"
+
+	big "github.com/Tymeshift/erlang-c-go/internal/bignum"
+)
+
+func TestLRUFactorialCache(t *testing.T) {
+	cache := NewLRUFactorialCache(2)
+	for n := int64(1); n <= 3; n++ {
+		getCachedFactorial(cache, n)
+	}
+	getCachedFactorial(cache, 3)
+	if _, ok := cache.Get(1); ok {
+		t.Errorf("least recently used factorial should be evicted")
+	}
+
+	stats := cache.Stats()
+	expected := CacheStats{Hits: 1, Misses: 4, Size: 2}
+	if stats != expected {
+		t.Errorf("stats should be %+v, got %+v", expected, stats)
+	}
+
+	cache.Clear()
+	stats = cache.Stats()
+	if stats != (CacheStats{}) {
+		t.Errorf("stats after clear should be empty, got %+v", stats)
+	}
+}
+
+func TestNoopFactorialCache(t *testing.T) {
+	cache := NewNoopFactorialCache()
+	res := getCachedFactorial(cache, 20)
+	res = getCachedFactorial(cache, 20)
+	if res.Cmp(big.NewInt(2432902008176640000)) != 0 {
+		t.Errorf("factorial result should be 2432902008176640000, got %s", res.String())
+	}
+	if stats := cache.Stats(); stats.Misses != 2 || stats.Size != 0 {
+		t.Errorf("noop cache should miss every time, got %+v", stats)
+	}
+}
+
+func TestTableFactorialCache(t *testing.T) {
+	cache := NewTableFactorialCache(100)
+	for n := int64(0); n <= 120; n += 20 {
+		res := getCachedFactorial(cache, n)
+		expected := getCachedFactorial(NewNoopFactorialCache(), n)
+		if res.Cmp(expected) != 0 {
+			t.Errorf("factorial of %d should be %s, got %s", n, expected.String(), res.String())
+		}
+	}
+	if stats := cache.Stats(); stats.Hits != 6 || stats.Misses != 1 || stats.Size != 101 {
+		t.Errorf("table cache should hit up to 100, got %+v", stats)
+	}
+}
+
+func TestSetFactorialCache(t *testing.T) {
+	previous := GetFactorialCache()
+	defer SetFactorialCache(previous)
+
+	cache := NewLRUFactorialCache(DefaultFactorialCacheSize)
+	SetFactorialCache(cache)
+	GetNumberOfAgents(FteParams{
+		ID:                 "1",
+		Volume:             10,
+		IntervalLength:     900,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+	})
+	if stats := cache.Stats(); stats.Misses == 0 {
+		t.Errorf("calculation should use the cache, got %+v", stats)
+	}
+}
+
+func TestFactorialCacheSmallerThanAgents(t *testing.T) {
+	cache := NewLRUFactorialCache(16)
+	calculator := NewCalculator(WithFactorialCache(cache))
+	params := FteParams{
+		ID:                 "1",
+		Volume:             300,
+		IntervalLength:     900,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+	}
+	expected := calculator.GetNumberOfAgents(params)
+	if expected.Volume <= 16 {
+		t.Fatalf("agents should outgrow the cache, got %d", expected.Volume)
+	}
+	first := cache.Stats()
+	if first.Misses > 2 {
+		t.Errorf("calculation should look up only a few factorials, got %+v", first)
+	}
+
+	if res := calculator.GetNumberOfAgents(params); res != expected {
+		t.Errorf("result should be %+v, got %+v", expected, res)
+	}
+	if stats := cache.Stats(); stats.Misses != first.Misses || stats.Hits <= first.Hits {
+		t.Errorf("repeated calculation should hit the cache, got %+v after %+v", stats, first)
+	}
+}
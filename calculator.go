@@ -0,0 +1,154 @@
+package erlangc
+
+import (
+	"math"
+	"runtime"
+	"strings"
+)
+
+// Logger - destination of calculation messages, *log.Logger satisfies it
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Rounding - conversion of fractional number of agents into the headcount
+type Rounding int
+
+const (
+	// RoundingCeil rounds agents up, never understaffing
+	RoundingCeil Rounding = iota
+	// RoundingNearest rounds agents to the nearest integer
+	RoundingNearest
+	// RoundingFloor rounds agents down
+	RoundingFloor
+)
+
+// Calculator - calculates number of agents with its own configuration,
+// package functions use a calculator with default options
+type Calculator struct {
+	engine       Engine
+	cache        FactorialCache
+	rounding     Rounding
+	limit        int
+	maxIntensity float64
+	maxAgents    int64
+	logger       Logger
+	strategies   map[string]Strategy
+}
+
+// Option - configuration of a Calculator
+type Option func(*Calculator)
+
+// WithEngine sets engine used for params with EngineDefault, EngineExact when not set
+func WithEngine(engine Engine) Option {
+	return func(c *Calculator) {
+		c.engine = engine
+	}
+}
+
+// WithFactorialCache sets cache of factorials used by the exact engine, the package cache when not set
+func WithFactorialCache(cache FactorialCache) Option {
+	return func(c *Calculator) {
+		c.cache = cache
+	}
+}
+
+// WithRounding sets rounding of the final number of agents, RoundingCeil when not set
+func WithRounding(rounding Rounding) Option {
+	return func(c *Calculator) {
+		c.rounding = rounding
+	}
+}
+
+// WithConcurrency sets the maximum number of goroutines of parallel calculations, runtime.GOMAXPROCS when not set
+func WithConcurrency(limit int) Option {
+	return func(c *Calculator) {
+		c.limit = limit
+	}
+}
+
+// WithMaxIntensity sets the highest traffic intensity in erlangs accepted by Validate, DefaultMaxIntensity when not set
+func WithMaxIntensity(maxIntensity float64) Option {
+	return func(c *Calculator) {
+		c.maxIntensity = maxIntensity
+	}
+}
+
+// WithMaxAgents sets the highest MinStaffing accepted by Validate, DefaultMaxAgents when not set
+func WithMaxAgents(maxAgents int64) Option {
+	return func(c *Calculator) {
+		c.maxAgents = maxAgents
+	}
+}
+
+// WithLogger sets logger of failed and unfinished rows, nothing is logged when not set
+func WithLogger(logger Logger) Option {
+	return func(c *Calculator) {
+		c.logger = logger
+	}
+}
+
+// WithStrategy sets strategy of the channel for this calculator only, overriding RegisterStrategy
+func WithStrategy(channel string, strategy Strategy) Option {
+	return func(c *Calculator) {
+		if c.strategies == nil {
+			c.strategies = make(map[string]Strategy)
+		}
+		c.strategies[strings.ToLower(channel)] = strategy
+	}
+}
+
+func NewCalculator(options ...Option) *Calculator {
+	c := &Calculator{engine: EngineExact}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+var defaultCalculator = NewCalculator()
+
+func (c *Calculator) getConcurrency() int {
+	if c.limit <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return c.limit
+}
+
+func (c *Calculator) getMaxIntensity() float64 {
+	if c.maxIntensity <= 0 {
+		return DefaultMaxIntensity
+	}
+	return c.maxIntensity
+}
+
+func (c *Calculator) getMaxAgents() int64 {
+	if c.maxAgents <= 0 {
+		return DefaultMaxAgents
+	}
+	return c.maxAgents
+}
+
+func (c *Calculator) getFactorialCache() FactorialCache {
+	if c.cache != nil {
+		return c.cache
+	}
+	return GetFactorialCache()
+}
+
+func (c *Calculator) round(agents float64) int64 {
+	switch c.rounding {
+	case RoundingNearest:
+		return int64(math.Round(agents))
+	case RoundingFloor:
+		return int64(math.Floor(agents))
+	default:
+		return int64(math.Ceil(agents))
+	}
+}
+
+func (c *Calculator) logf(format string, v ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, v...)
+	}
+}
@@ -0,0 +1,76 @@
+package erlangc
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestCalculator(t *testing.T) {
+	params := []FteParams{
+		{ID: "1", Index: 0, Volume: 10, IntervalLength: 900, MaxOccupancy: 0.8, Shrinkage: 0.3, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 60},
+		{ID: "1", Index: 1, Volume: 100, IntervalLength: 900, MaxOccupancy: 0.8, Shrinkage: 0.3, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 60, Channel: "email"},
+	}
+	expected := CalculateFte(params)
+
+	cache := NewLRUFactorialCache(100)
+	exact := NewCalculator(WithFactorialCache(cache))
+	float := NewCalculator(WithEngine(EngineFloat), WithFactorialCache(NewNoopFactorialCache()))
+	for _, calculator := range []*Calculator{exact, float} {
+		fte := calculator.CalculateFteParallel(params)
+		for i := range expected {
+			if fte[i].Volume != expected[i].Volume {
+				t.Errorf("agents of row %d should be %d, got %d", i, expected[i].Volume, fte[i].Volume)
+			}
+		}
+	}
+	if stats := cache.Stats(); stats.Misses == 0 || stats.Size == 0 {
+		t.Errorf("exact calculator should use its own cache, got %+v", stats)
+	}
+
+	params[0].Engine = EngineExact
+	if res := float.GetNumberOfAgents(params[0]); res != expected[0] {
+		t.Errorf("engine of params should override calculator engine, got %+v", res)
+	}
+
+	for rounding, expected := range map[Rounding]int64{RoundingCeil: 9, RoundingNearest: 8, RoundingFloor: 8} {
+		if res := NewCalculator(WithRounding(rounding)).round(8.4); res != expected {
+			t.Errorf("rounding %d of 8.4 should be %d, got %d", rounding, expected, res)
+		}
+	}
+}
+
+func TestCalculatorStrategyAndLogger(t *testing.T) {
+	var logs bytes.Buffer
+	calculator := NewCalculator(
+		WithEngine(EngineFloat),
+		WithConcurrency(2),
+		WithLogger(log.New(&logs, "", 0)),
+		WithStrategy("Email", func(ctx context.Context, calculator *Calculator, fteParams FteParams) (FteResult, error) {
+			fteParams.Channel = "voice"
+			return calculator.GetNumberOfAgentsContext(ctx, fteParams)
+		}),
+	)
+	params := []FteParams{
+		{ID: "1", Index: 0, Volume: 10, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 60, Channel: "email"},
+		{ID: "1", Index: 1, Volume: 10, IntervalLength: 0, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 60},
+	}
+
+	fte, err := calculator.CalculateFteChecked(params)
+	if err == nil {
+		t.Fatal("second row should be invalid")
+	}
+	erlang := params[0]
+	erlang.Channel = ""
+	if fte[0].Volume != GetNumberOfAgents(erlang).Volume || fte[0].Constraint != ConstraintServiceLevel {
+		t.Errorf("email should use the calculator strategy, got %+v", fte[0])
+	}
+	if !strings.Contains(logs.String(), "1 of 2 rows failed") {
+		t.Errorf("failed rows should be logged, got %q", logs.String())
+	}
+	if res := CalculateFte(params[:1]); res[0].Constraint != ConstraintWorkload {
+		t.Errorf("default calculator should keep registered strategy, got %+v", res[0])
+	}
+}
@@ -0,0 +1,92 @@
+package erlangc
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Strategy - calculates number of agents for parameters of a specific channel with the calculator's configuration,
+// long running strategies should stop with ctx.Err() when ctx is done. Strategies wrapping the Erlang calculation
+// call GetNumberOfAgentsErlang, GetNumberOfAgentsContext would dispatch to the strategy of the channel again.
+type Strategy func(ctx context.Context, calculator *Calculator, fteParams FteParams) (FteResult, error)
+
+var strategies = map[string]Strategy{
+	"voice":      getNumberOfAgentsVoice,
+	"chat":       getNumberOfAgents,
+	"email":      getNumberOfAgentsWorkload,
+	"backoffice": getNumberOfAgentsWorkload,
+}
+var strategiesMutex = &sync.RWMutex{}
+
+// RegisterStrategy sets the strategy CalculateFte uses for params of the channel, replacing any existing one
+func RegisterStrategy(channel string, strategy Strategy) {
+	strategiesMutex.Lock()
+	strategies[strings.ToLower(channel)] = strategy
+	strategiesMutex.Unlock()
+}
+
+// runStrategy calculates number of agents with the strategy of the channel of the params,
+// all entry points go through it so they treat channels alike
+func (c *Calculator) runStrategy(ctx context.Context, fteParams FteParams) (FteResult, error) {
+	return c.getStrategy(fteParams.Channel)(ctx, c, fteParams)
+}
+
+// runChecked validates params before runStrategy, error-returning entry points go through it
+// so invalid rows are reported instead of calculated
+func (c *Calculator) runChecked(ctx context.Context, fteParams FteParams) (FteResult, error) {
+	if err := c.Validate(fteParams); err != nil {
+		return getEmptyResult(fteParams), err
+	}
+	return c.runStrategy(ctx, fteParams)
+}
+
+// getStrategy returns strategy of the channel set on the calculator or registered with RegisterStrategy,
+// Erlang C or A is used for unknown channels
+func (c *Calculator) getStrategy(channel string) Strategy {
+	channel = strings.ToLower(channel)
+	if strategy, ok := c.strategies[channel]; ok {
+		return strategy
+	}
+	strategiesMutex.RLock()
+	strategy, ok := strategies[channel]
+	strategiesMutex.RUnlock()
+	if ok {
+		return strategy
+	}
+	return getNumberOfAgents
+}
+
+func getNumberOfAgents(ctx context.Context, calculator *Calculator, fteParams FteParams) (FteResult, error) {
+	return calculator.GetNumberOfAgentsErlang(ctx, fteParams)
+}
+
+func getNumberOfAgentsVoice(ctx context.Context, calculator *Calculator, fteParams FteParams) (FteResult, error) {
+	fteParams.Concurrency = 0
+	return calculator.GetNumberOfAgentsErlang(ctx, fteParams)
+}
+
+func getNumberOfAgentsWorkload(ctx context.Context, calculator *Calculator, fteParams FteParams) (FteResult, error) {
+	return calculator.GetNumberOfAgentsWorkload(fteParams), nil
+}
+
+// GetNumberOfAgentsWorkload calculates number of agents for deferred work (email, back office)
+// as volume * aht / intervalLength, when targetTime is shorter than the interval the work has to be done within targetTime
+func GetNumberOfAgentsWorkload(fteParams FteParams) FteResult {
+	return defaultCalculator.GetNumberOfAgentsWorkload(fteParams)
+}
+
+func (c *Calculator) GetNumberOfAgentsWorkload(fteParams FteParams) FteResult {
+	fteParams.Concurrency = 0
+	intensity := 0.0
+	if fteParams.Volume > 0 && fteParams.Aht > 0 {
+		period := fteParams.IntervalLength
+		if fteParams.TargetTime > 0 && fteParams.TargetTime < period {
+			period = fteParams.TargetTime
+		}
+		intensity = getIntensity(fteParams.Volume, fteParams.Aht, period)
+	}
+
+	result, _ := c.getFteResult(fteParams, intensity, intensity, ConstraintWorkload)
+	return result
+}
@@ -0,0 +1,130 @@
+package erlangc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetNumberOfAgentsWorkload(t *testing.T) {
+	params := FteParams{
+		ID:             "1",
+		Index:          0,
+		Volume:         30,
+		IntervalLength: 3600,
+		MaxOccupancy:   0.8,
+		Shrinkage:      0.2,
+		Aht:            600,
+		TargetTime:     86400,
+		Channel:        "email",
+	}
+	num := GetNumberOfAgentsWorkload(params)
+	answer := int64(9)
+	if num.Volume != answer {
+		t.Errorf("workload agents should be %d, got %d", answer, num.Volume)
+	}
+
+	params.TargetTime = 1800
+	num = GetNumberOfAgentsWorkload(params)
+	answer = int64(17)
+	if num.Volume != answer {
+		t.Errorf("workload agents with 30 minutes response time should be %d, got %d", answer, num.Volume)
+	}
+
+	params.Volume = 0
+	num = GetNumberOfAgentsWorkload(params)
+	if num.Volume != 0 {
+		t.Errorf("workload agents without volume should be 0, got %d", num.Volume)
+	}
+}
+
+func TestCalculateFteChannels(t *testing.T) {
+	RegisterStrategy("Custom", func(ctx context.Context, calculator *Calculator, fteParams FteParams) (FteResult, error) {
+		return FteResult{ID: fteParams.ID, Index: fteParams.Index, Volume: 42}, nil
+	})
+	defer func() {
+		strategiesMutex.Lock()
+		delete(strategies, "custom")
+		strategiesMutex.Unlock()
+	}()
+
+	base := FteParams{
+		ID:                 "1",
+		Volume:             100,
+		IntervalLength:     900,
+		MaxOccupancy:       0.8,
+		Shrinkage:          0.2,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+		Concurrency:        3,
+	}
+	channels := []string{"", "voice", "chat", "email", "custom"}
+	params := make([]FteParams, len(channels))
+	for i, channel := range channels {
+		params[i] = base
+		params[i].Index = int64(i)
+		params[i].Channel = channel
+	}
+
+	expected := []int64{
+		GetNumberOfAgents(base).Volume,
+		53,
+		GetNumberOfAgents(base).Volume,
+		GetNumberOfAgentsWorkload(base).Volume,
+		42,
+	}
+	for i, res := range CalculateFte(params) {
+		if res.Volume != expected[i] {
+			t.Errorf("agents for channel %q should be %d, got %d", channels[i], expected[i], res.Volume)
+		}
+	}
+}
+
+func TestGetNumberOfAgentsChannel(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Volume:             100,
+		IntervalLength:     900,
+		MaxOccupancy:       0.8,
+		Shrinkage:          0.2,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+		Concurrency:        3,
+		Channel:            "voice",
+	}
+	// direct calls apply the channel like batches do
+	expected := CalculateFte([]FteParams{params})[0]
+	if res := GetNumberOfAgents(params); res != expected {
+		t.Errorf("voice agents should be %+v, got %+v", expected, res)
+	}
+	params.Channel = "email"
+	if res := GetNumberOfAgents(params); res != GetNumberOfAgentsWorkload(params) {
+		t.Errorf("email agents should be the workload, got %+v", res)
+	}
+
+	// strategies wrap Erlang C with GetNumberOfAgentsErlang
+	calculator := NewCalculator(WithStrategy("wrapped", func(ctx context.Context, calculator *Calculator, fteParams FteParams) (FteResult, error) {
+		result, err := calculator.GetNumberOfAgentsErlang(ctx, fteParams)
+		result.Volume++
+		return result, err
+	}))
+	params.Channel = "wrapped"
+	erlang := params
+	erlang.Channel = ""
+	if res := calculator.GetNumberOfAgents(params); res.Volume != GetNumberOfAgents(erlang).Volume+1 {
+		t.Errorf("wrapped strategy should add an agent to %d, got %d", GetNumberOfAgents(erlang).Volume, res.Volume)
+	}
+
+	// contexts of strategies don't change dispatch of other calculations
+	calculator = NewCalculator(WithStrategy("nested", func(ctx context.Context, calculator *Calculator, fteParams FteParams) (FteResult, error) {
+		fteParams.Channel = "email"
+		return calculator.GetNumberOfAgentsContext(ctx, fteParams)
+	}))
+	params.Channel = "nested"
+	email := params
+	email.Channel = "email"
+	if res := calculator.GetNumberOfAgents(params); res != GetNumberOfAgentsWorkload(email) {
+		t.Errorf("nested call should use the email strategy, got %+v", res)
+	}
+}
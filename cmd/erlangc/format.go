@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+	"github.com/Tymeshift/erlang-c-go/csvcodec"
+)
+
+const (
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+	formatCSV    = "csv"
+)
+
+// getFormat returns format of the file from its extension, json when unknown
+func getFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ndjson", ".jsonl":
+		return formatNDJSON
+	case ".csv":
+		return formatCSV
+	default:
+		return formatJSON
+	}
+}
+
+func readParams(r io.Reader, format string, csvOptions []csvcodec.Option) ([]erlangc.FteParams, error) {
+	switch format {
+	case formatJSON:
+		var params []erlangc.FteParams
+		err := json.NewDecoder(r).Decode(&params)
+		return params, err
+	case formatNDJSON:
+		var params []erlangc.FteParams
+		dec := json.NewDecoder(r)
+		for {
+			var param erlangc.FteParams
+			err := dec.Decode(&param)
+			if err == io.EOF {
+				return params, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", len(params), err)
+			}
+			params = append(params, param)
+		}
+	case formatCSV:
+		return csvcodec.NewParamsReader(r, csvOptions...).ReadAll()
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+func writeResults(w io.Writer, fte []erlangc.FteResult, format string) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "    ")
+		return enc.Encode(fte)
+	case formatNDJSON:
+		buf := bufio.NewWriter(w)
+		enc := json.NewEncoder(buf)
+		for _, res := range fte {
+			if err := enc.Encode(res); err != nil {
+				return err
+			}
+		}
+		return buf.Flush()
+	case formatCSV:
+		return csvcodec.NewResultWriter(w).WriteAll(fte)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// getCSVOptions returns codec options of comma separated header=Field and Field=scale pairs
+func getCSVOptions(columns string, scales string, ignoreUnknown bool) ([]csvcodec.Option, error) {
+	var options []csvcodec.Option
+	for _, pair := range splitPairs(columns) {
+		header, field, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("column %q should be header=Field", pair)
+		}
+		options = append(options, csvcodec.WithColumn(strings.TrimSpace(header), strings.TrimSpace(field)))
+	}
+	for _, pair := range splitPairs(scales) {
+		field, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("scale %q should be Field=scale", pair)
+		}
+		scale, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("scale %q: %w", pair, err)
+		}
+		options = append(options, csvcodec.WithScale(strings.TrimSpace(field), scale))
+	}
+	if ignoreUnknown {
+		options = append(options, csvcodec.WithIgnoreUnknown())
+	}
+	return options, nil
+}
+
+func splitPairs(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
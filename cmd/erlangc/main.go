@@ -0,0 +1,232 @@
+// Command erlangc calculates required number of agents for rows of FteParams.
+//
+// Usage:
+//
+//	erlangc [flags] [file]
+//	erlangc serve [flags]
+//
+// Rows are read from the file, or stdin when it is omitted or "-", as a JSON array,
+// newline delimited JSON or CSV. CSV columns are FteParams field names unless they are
+// mapped with -columns, and -scale converts their units, e.g.
+//
+//	erlangc -columns calls=Volume,aht_min=Aht,sl=TargetServiceLevel -scale Aht=60,TargetServiceLevel=0.01 forecast.csv
+//
+// Results are written to stdout in the same format unless -out is set. Flags like
+// -shrinkage or -target-sl override the value of every row.
+//
+// The serve subcommand exposes the calculation over HTTP and optionally gRPC,
+// see packages server and grpcserver.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "erlangc:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+var engines = map[string]erlangc.Engine{
+	"exact":  erlangc.EngineExact,
+	"float":  erlangc.EngineFloat,
+	"approx": erlangc.EngineApprox,
+}
+
+var roundings = map[string]erlangc.Rounding{
+	"ceil":    erlangc.RoundingCeil,
+	"nearest": erlangc.RoundingNearest,
+	"floor":   erlangc.RoundingFloor,
+}
+
+// overrides - values replacing the ones of every row, only flags set on the command line are applied
+type overrides struct {
+	shrinkage          float64
+	targetServiceLevel float64
+	targetTime         int64
+	targetAsa          int64
+	maxOccupancy       float64
+	intervalLength     int64
+	minStaffing        int64
+	patience           int64
+	channel            string
+}
+
+func (o *overrides) register(flags *flag.FlagSet) {
+	flags.Float64Var(&o.shrinkage, "shrinkage", 0, "override shrinkage, 0.3 for 30%")
+	flags.Float64Var(&o.targetServiceLevel, "target-sl", 0, "override target service level, 0.8 for 80%")
+	flags.Int64Var(&o.targetTime, "target-time", 0, "override target answer time in seconds")
+	flags.Int64Var(&o.targetAsa, "target-asa", 0, "override target average speed of answer in seconds")
+	flags.Float64Var(&o.maxOccupancy, "max-occupancy", 0, "override max occupancy, 0.85 for 85%")
+	flags.Int64Var(&o.intervalLength, "interval", 0, "override interval length in seconds")
+	flags.Int64Var(&o.minStaffing, "min-staffing", 0, "override minimum number of agents")
+	flags.Int64Var(&o.patience, "patience", 0, "override average patience of callers in seconds")
+	flags.StringVar(&o.channel, "channel", "", "override channel")
+}
+
+func (o *overrides) apply(flags *flag.FlagSet, params []erlangc.FteParams) {
+	flags.Visit(func(f *flag.Flag) {
+		for i := range params {
+			p := &params[i]
+			switch f.Name {
+			case "shrinkage":
+				p.Shrinkage = o.shrinkage
+			case "target-sl":
+				p.TargetServiceLevel = o.targetServiceLevel
+			case "target-time":
+				p.TargetTime = o.targetTime
+			case "target-asa":
+				p.TargetAsa = o.targetAsa
+			case "max-occupancy":
+				p.MaxOccupancy = o.maxOccupancy
+			case "interval":
+				p.IntervalLength = o.intervalLength
+			case "min-staffing":
+				p.MinStaffing = o.minStaffing
+			case "patience":
+				p.Patience = o.patience
+			case "channel":
+				p.Channel = o.channel
+			}
+		}
+	})
+}
+
+// getCalculatorOptions returns options of the engine and rounding named by flags
+func getCalculatorOptions(engine string, rounding string) ([]erlangc.Option, error) {
+	calcEngine, ok := engines[strings.ToLower(engine)]
+	if !ok {
+		return nil, fmt.Errorf("unknown engine %q", engine)
+	}
+	calcRounding, ok := roundings[strings.ToLower(rounding)]
+	if !ok {
+		return nil, fmt.Errorf("unknown rounding %q", rounding)
+	}
+	return []erlangc.Option{erlangc.WithEngine(calcEngine), erlangc.WithRounding(calcRounding)}, nil
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	if len(args) > 0 && args[0] == "serve" {
+		return serve(ctx, args[1:], stderr)
+	}
+	return calculate(ctx, args, stdin, stdout, stderr)
+}
+
+func calculate(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("erlangc", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: erlangc [flags] [file]")
+		flags.PrintDefaults()
+	}
+	inFormat := flags.String("in", "", "input format: json, ndjson or csv, from the file extension when not set")
+	outFormat := flags.String("out", "", "output format: json, ndjson or csv, the input format when not set")
+	output := flags.String("o", "", "output file, stdout when not set")
+	parallel := flags.Bool("parallel", false, "calculate rows in parallel")
+	workers := flags.Int("workers", 0, "maximum number of parallel calculations, GOMAXPROCS when not set")
+	maxIntensity := flags.Float64("max-intensity", erlangc.DefaultMaxIntensity, "maximum traffic intensity of a row in erlangs")
+	maxAgents := flags.Int64("max-agents", erlangc.DefaultMaxAgents, "maximum minimum staffing of a row")
+	engine := flags.String("engine", "exact", "engine of rows without one: exact, float or approx")
+	rounding := flags.String("rounding", "ceil", "rounding of agents: ceil, nearest or floor")
+	columns := flags.String("columns", "", "mapping of input CSV columns to fields, e.g. calls=Volume,aht_sec=Aht")
+	scales := flags.String("scale", "", "factors converting input CSV values into fields, e.g. Aht=60 for AHT in minutes")
+	ignoreUnknown := flags.Bool("ignore-unknown", false, "ignore input CSV columns not matching any field")
+	var o overrides
+	o.register(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return fmt.Errorf("expected at most one input file, got %d", flags.NArg())
+	}
+
+	options, err := getCalculatorOptions(*engine, *rounding)
+	if err != nil {
+		return err
+	}
+	csvOptions, err := getCSVOptions(*columns, *scales, *ignoreUnknown)
+	if err != nil {
+		return err
+	}
+
+	input := flags.Arg(0)
+	in := stdin
+	if input != "" && input != "-" {
+		file, err := os.Open(input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	if *inFormat == "" {
+		*inFormat = getFormat(input)
+	}
+	if *outFormat == "" {
+		*outFormat = *inFormat
+	}
+
+	params, err := readParams(in, strings.ToLower(*inFormat), csvOptions)
+	var rowErr *erlangc.RowError
+	if errors.As(err, &rowErr) {
+		fmt.Fprintln(stderr, err)
+		return fmt.Errorf("reading %s: invalid rows", *inFormat)
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", *inFormat, err)
+	}
+	o.apply(flags, params)
+
+	calculator := erlangc.NewCalculator(append(options, erlangc.WithConcurrency(*workers), erlangc.WithMaxIntensity(*maxIntensity), erlangc.WithMaxAgents(*maxAgents))...)
+	invalid := 0
+	for i, param := range params {
+		if err := calculator.Validate(param); err != nil {
+			fmt.Fprintln(stderr, &erlangc.RowError{Row: i, ID: param.ID, Index: param.Index, Err: err})
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d rows are invalid", invalid, len(params))
+	}
+
+	var fte []erlangc.FteResult
+	if *parallel {
+		fte, err = calculator.CalculateFteParallelContext(ctx, params)
+	} else {
+		fte, err = calculator.CalculateFteContext(ctx, params)
+	}
+	if err != nil {
+		return err
+	}
+
+	format := strings.ToLower(*outFormat)
+	if *output == "" {
+		return writeResults(stdout, fte, format)
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := writeResults(file, fte, format); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+	"github.com/Tymeshift/erlang-c-go/csvcodec"
+)
+
+const csvParams = `ID,Index,Volume,IntervalLength,Aht,TargetServiceLevel,TargetTime,MaxOccupancy,Shrinkage
+1,0,500,900,300,0.8,20,0.85,0.3
+1,1,250,900,300,0.8,20,0.85,0.3
+`
+
+func TestRunFormats(t *testing.T) {
+	params, err := csvcodec.NewParamsReader(strings.NewReader(csvParams)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := erlangc.CalculateFte(params)
+
+	var jsonIn bytes.Buffer
+	if err := json.NewEncoder(&jsonIn).Encode(params); err != nil {
+		t.Fatal(err)
+	}
+	var ndjsonIn bytes.Buffer
+	for _, param := range params {
+		if err := json.NewEncoder(&ndjsonIn).Encode(param); err != nil {
+			t.Fatal(err)
+		}
+	}
+	inputs := map[string]string{
+		formatJSON:   jsonIn.String(),
+		formatNDJSON: ndjsonIn.String(),
+		formatCSV:    csvParams,
+	}
+	for format, input := range inputs {
+		var stdout, stderr bytes.Buffer
+		err := run(context.Background(), []string{"-in", format, "-out", formatNDJSON, "-parallel"}, strings.NewReader(input), &stdout, &stderr)
+		if err != nil {
+			t.Fatalf("%s: %v, %s", format, err, stderr.String())
+		}
+		dec := json.NewDecoder(&stdout)
+		for i := range expected {
+			var res erlangc.FteResult
+			if err := dec.Decode(&res); err != nil {
+				t.Fatalf("%s: %v", format, err)
+			}
+			if res.Volume != expected[i].Volume {
+				t.Errorf("%s: row %d should have %d agents, got %d", format, i, expected[i].Volume, res.Volume)
+			}
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = run(context.Background(), []string{"-in", formatCSV}, strings.NewReader(csvParams), &stdout, &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != len(params)+1 || !strings.HasPrefix(lines[0], "ID,Index,Timestamp,Volume") {
+		t.Errorf("csv output should have a header and %d rows, got %q", len(params), stdout.String())
+	}
+}
+
+func TestRunOverrides(t *testing.T) {
+	params, err := csvcodec.NewParamsReader(strings.NewReader(csvParams)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range params {
+		params[i].Shrinkage = 0
+		params[i].TargetServiceLevel = 0.9
+	}
+	expected := erlangc.CalculateFte(params)
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"-in", formatCSV, "-out", formatJSON, "-shrinkage", "0", "-target-sl", "0.9"}
+	if err := run(context.Background(), args, strings.NewReader(csvParams), &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	var fte []erlangc.FteResult
+	if err := json.Unmarshal(stdout.Bytes(), &fte); err != nil {
+		t.Fatal(err)
+	}
+	for i := range expected {
+		if fte[i].Volume != expected[i].Volume {
+			t.Errorf("row %d should have %d agents, got %d", i, expected[i].Volume, fte[i].Volume)
+		}
+	}
+
+	stdout.Reset()
+	err = run(context.Background(), []string{"-in", formatCSV, "-target-sl", "2"}, strings.NewReader(csvParams), &stdout, &stderr)
+	if err == nil {
+		t.Error("invalid target service level should fail")
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("invalid rows should not write results, got %q", stdout.String())
+	}
+
+	err = run(context.Background(), []string{"-in", formatCSV, "-max-intensity", "100"}, strings.NewReader(csvParams), &stdout, &stderr)
+	if err == nil || !strings.Contains(stderr.String(), "Volume") {
+		t.Errorf("row of 166 erlangs should exceed -max-intensity 100, got %v, %s", err, stderr.String())
+	}
+}
+
+func TestRunColumns(t *testing.T) {
+	params, err := csvcodec.NewParamsReader(strings.NewReader(csvParams)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := erlangc.CalculateFte(params)
+
+	input := `queue,Index,calls,aht_min,IntervalLength,sl,TargetTime,MaxOccupancy,Shrinkage,notes
+1,0,500,5,900,80,20,0.85,0.3,peak
+1,1,250,5,900,80,20,0.85,0.3,
+`
+	var stdout, stderr bytes.Buffer
+	args := []string{"-in", formatCSV, "-out", formatJSON, "-ignore-unknown",
+		"-columns", "queue=ID,calls=Volume,aht_min=Aht,sl=TargetServiceLevel", "-scale", "Aht=60,TargetServiceLevel=0.01"}
+	if err := run(context.Background(), args, strings.NewReader(input), &stdout, &stderr); err != nil {
+		t.Fatal(err, stderr.String())
+	}
+	var fte []erlangc.FteResult
+	if err := json.Unmarshal(stdout.Bytes(), &fte); err != nil {
+		t.Fatal(err)
+	}
+	for i := range expected {
+		if fte[i] != expected[i] {
+			t.Errorf("row %d should be %+v, got %+v", i, expected[i], fte[i])
+		}
+	}
+
+	stderr.Reset()
+	err = run(context.Background(), []string{"-in", formatCSV}, strings.NewReader("ID,Volume\na,many\n"), &stdout, &stderr)
+	if err == nil || !strings.Contains(stderr.String(), "row 0") {
+		t.Errorf("invalid cell should be reported, got %v, %q", err, stderr.String())
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"time"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+	"github.com/Tymeshift/erlang-c-go/grpcserver"
+	"github.com/Tymeshift/erlang-c-go/grpcserver/erlangcpb"
+	"github.com/Tymeshift/erlang-c-go/server"
+	"google.golang.org/grpc"
+)
+
+func serve(ctx context.Context, args []string, stderr io.Writer) error {
+	flags := flag.NewFlagSet("erlangc serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: erlangc serve [flags]")
+		flags.PrintDefaults()
+	}
+	addr := flags.String("addr", ":8080", "address to listen on")
+	grpcAddr := flags.String("grpc-addr", "", "address to serve gRPC on, gRPC is disabled when not set")
+	maxBodyBytes := flags.Int64("max-body", server.DefaultMaxBodyBytes, "maximum size of a request body in bytes")
+	maxRows := flags.Int("max-rows", server.DefaultMaxRows, "maximum number of rows of a request")
+	timeout := flags.Duration("timeout", 0, "maximum duration of a calculation, not limited when 0")
+	shutdownTimeout := flags.Duration("shutdown-timeout", server.DefaultShutdownTimeout, "time given to requests in flight on shutdown")
+	workers := flags.Int("workers", 0, "maximum number of parallel calculations of a request, GOMAXPROCS when not set")
+	maxIntensity := flags.Float64("max-intensity", erlangc.DefaultMaxIntensity, "maximum traffic intensity of a row in erlangs")
+	maxAgents := flags.Int64("max-agents", erlangc.DefaultMaxAgents, "maximum minimum staffing of a row")
+	engine := flags.String("engine", "exact", "engine of rows without one: exact, float or approx")
+	rounding := flags.String("rounding", "ceil", "rounding of agents: ceil, nearest or floor")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return fmt.Errorf("unexpected arguments %v", flags.Args())
+	}
+
+	options, err := getCalculatorOptions(*engine, *rounding)
+	if err != nil {
+		return err
+	}
+	logger := log.New(stderr, "", log.LstdFlags)
+	calculator := erlangc.NewCalculator(append(options, erlangc.WithConcurrency(*workers), erlangc.WithMaxIntensity(*maxIntensity), erlangc.WithMaxAgents(*maxAgents), erlangc.WithLogger(logger))...)
+	s := server.New(calculator,
+		server.WithMaxBodyBytes(*maxBodyBytes),
+		server.WithMaxRows(*maxRows),
+		server.WithTimeout(*timeout),
+		server.WithShutdownTimeout(*shutdownTimeout),
+	)
+
+	// gRPC stops with HTTP, also when HTTP fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	grpcStopped := make(chan struct{})
+	if *grpcAddr == "" {
+		close(grpcStopped)
+	} else {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			return err
+		}
+		grpcServer := grpc.NewServer()
+		erlangcpb.RegisterErlangCServiceServer(grpcServer, grpcserver.New(calculator, grpcserver.WithMaxRows(*maxRows)))
+		go func() {
+			defer close(grpcStopped)
+			<-ctx.Done()
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			// streams in flight get the shutdown timeout like HTTP requests
+			timer := time.NewTimer(*shutdownTimeout)
+			defer timer.Stop()
+			select {
+			case <-stopped:
+			case <-timer.C:
+				grpcServer.Stop()
+				<-stopped
+			}
+		}()
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.Printf("erlangc: gRPC: %v", err)
+			}
+		}()
+		logger.Printf("erlangc: serving gRPC on %s", *grpcAddr)
+	}
+
+	logger.Printf("erlangc: listening on %s", *addr)
+	err = s.ListenAndServe(ctx, *addr)
+	cancel()
+	<-grpcStopped
+	logger.Printf("erlangc: stopped")
+	return err
+}
@@ -0,0 +1,20 @@
+package erlangc
+
+import "math"
+
+// getConcurrentAht returns handle time of a single session when an agent works on several sessions at once,
+// every extra concurrent session makes each of them longer by ahtInflation share of aht
+func getConcurrentAht(aht int64, concurrency int64, ahtInflation float64) int64 {
+	if concurrency <= 1 {
+		return aht
+	}
+	return int64(math.Round(float64(aht) * (1 + ahtInflation*float64(concurrency-1))))
+}
+
+// ApplyConcurrency converts number of concurrent sessions into number of agents
+func ApplyConcurrency(sessions float64, concurrency int64) float64 {
+	if concurrency <= 1 {
+		return sessions
+	}
+	return sessions / float64(concurrency)
+}
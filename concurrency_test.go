@@ -0,0 +1,45 @@
+package erlangc
+
+import "testing"
+
+func TestGetConcurrentAht(t *testing.T) {
+	res := getConcurrentAht(300, 1, 0.2)
+	if res != 300 {
+		t.Errorf("aht without concurrency should be 300, got %d", res)
+	}
+
+	res = getConcurrentAht(300, 3, 0.2)
+	if res != 420 {
+		t.Errorf("aht with 3 sessions should be 420, got %d", res)
+	}
+}
+
+func TestGetNumberOfAgentsConcurrency(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Index:              0,
+		Volume:             100,
+		IntervalLength:     900,
+		MaxOccupancy:       0.8,
+		Shrinkage:          0.2,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+		Channel:            "chat",
+	}
+	voice := GetNumberOfAgents(params)
+
+	params.Concurrency = 1
+	single := GetNumberOfAgents(params)
+	if single.Volume != voice.Volume {
+		t.Errorf("concurrency of 1 should need %d agents, got %d", voice.Volume, single.Volume)
+	}
+
+	params.Concurrency = 3
+	params.ConcurrencyAhtInflation = 0.2
+	chat := GetNumberOfAgents(params)
+	answer := int64(25)
+	if chat.Volume != answer {
+		t.Errorf("concurrency of 3 should need %d agents, got %d", answer, chat.Volume)
+	}
+}
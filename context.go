@@ -0,0 +1,127 @@
+package erlangc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// UnfinishedError - rows left without result because the context was done
+type UnfinishedError struct {
+	Rows []int
+	Err  error
+}
+
+func (e *UnfinishedError) Error() string {
+	return fmt.Sprintf("%d rows unfinished: %s", len(e.Rows), e.Err)
+}
+
+func (e *UnfinishedError) Unwrap() error {
+	return e.Err
+}
+
+// getBatchError returns strategy failures as *RowError and rows without result as *UnfinishedError
+func (c *Calculator) getBatchError(ctx context.Context, params []FteParams, finished []bool, errs []error) error {
+	var batchErrs []error
+	var unfinished []int
+	failed := 0
+	for i, param := range params {
+		switch {
+		case !finished[i] || (errs[i] != nil && ctx.Err() != nil):
+			unfinished = append(unfinished, i)
+		case errs[i] != nil:
+			batchErrs = append(batchErrs, &RowError{Row: i, ID: param.ID, Index: param.Index, Err: errs[i]})
+			failed++
+		}
+	}
+	if len(unfinished) > 0 {
+		batchErrs = append(batchErrs, &UnfinishedError{Rows: unfinished, Err: ctx.Err()})
+	}
+	if len(batchErrs) > 0 {
+		c.logf("erlangc: %d of %d rows failed, %d unfinished", failed, len(params), len(unfinished))
+	}
+	return errors.Join(batchErrs...)
+}
+
+// CalculateFteContext calculates number of agents like CalculateFte, rows with invalid params are reported
+// as *RowError like in CalculateFteChecked. When ctx is done the calculation stops,
+// rows without result have only ID, Index and Timestamp set and are listed in *UnfinishedError.
+func CalculateFteContext(ctx context.Context, params []FteParams) ([]FteResult, error) {
+	return defaultCalculator.CalculateFteContext(ctx, params)
+}
+
+func (c *Calculator) CalculateFteContext(ctx context.Context, params []FteParams) ([]FteResult, error) {
+	fte := make([]FteResult, len(params))
+	finished := make([]bool, len(params))
+	errs := make([]error, len(params))
+	for i, param := range params {
+		fte[i] = getEmptyResult(param)
+		if ctx.Err() != nil {
+			continue
+		}
+		result, err := c.runChecked(ctx, param)
+		if err == nil {
+			fte[i] = result
+		}
+		errs[i] = err
+		finished[i] = true
+	}
+
+	return fte, c.getBatchError(ctx, params, finished, errs)
+}
+
+// CalculateFteParallelContext calculates number of agents like CalculateFteParallel with at most as many
+// goroutines as the calculator's WithConcurrency (runtime.GOMAXPROCS for package functions),
+// results keep the order of params and rows with invalid params are reported as *RowError.
+// When ctx is done the calculation stops, rows without result have only ID, Index and Timestamp set
+// and are listed in *UnfinishedError.
+func CalculateFteParallelContext(ctx context.Context, params []FteParams) ([]FteResult, error) {
+	return defaultCalculator.CalculateFteParallelContext(ctx, params)
+}
+
+func (c *Calculator) CalculateFteParallelContext(ctx context.Context, params []FteParams) ([]FteResult, error) {
+	return c.calculateParallel(ctx, params, c.runChecked)
+}
+
+// calculateParallel calculates rows with run in parallel, keeping the order of params
+func (c *Calculator) calculateParallel(ctx context.Context, params []FteParams, run func(context.Context, FteParams) (FteResult, error)) ([]FteResult, error) {
+	limit := c.getConcurrency()
+	fte := make([]FteResult, len(params))
+	finished := make([]bool, len(params))
+	errs := make([]error, len(params))
+	for i, param := range params {
+		fte[i] = getEmptyResult(param)
+	}
+
+	rows := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < limit; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rows {
+				result, err := run(ctx, params[i])
+				if err == nil {
+					fte[i] = result
+				}
+				errs[i] = err
+				finished[i] = true
+			}
+		}()
+	}
+
+	for i := range params {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case rows <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(rows)
+	wg.Wait()
+
+	return fte, c.getBatchError(ctx, params, finished, errs)
+}
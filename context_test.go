@@ -0,0 +1,128 @@
+package erlangc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetNumberOfAgentsContext(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Index:              3,
+		Volume:             30000,
+		IntervalLength:     900,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	res, err := GetNumberOfAgentsContext(ctx, params)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error should be %s, got %v", context.DeadlineExceeded, err)
+	}
+	if res.Index != 3 || res.Volume != 0 {
+		t.Errorf("unfinished result should be empty, got %+v", res)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("search should stop soon after the deadline, took %s", elapsed)
+	}
+
+	params.Volume = 10
+	res, err = GetNumberOfAgentsContext(context.Background(), params)
+	if err != nil || res != GetNumberOfAgents(params) {
+		t.Errorf("result should match GetNumberOfAgents, got %+v, %v", res, err)
+	}
+}
+
+func TestGetNumberOfAgentsContextExpired(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Volume:             30000,
+		IntervalLength:     900,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+		Engine:             EngineExact,
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	start := time.Now()
+	_, err := GetNumberOfAgentsContext(ctx, params)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error should be %s, got %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("expired search of 10000 erlangs should return at once, took %s", elapsed)
+	}
+}
+
+func TestGetNumberOfAgentsContextInvalid(t *testing.T) {
+	valid := FteParams{ID: "1", Index: 4, Volume: 100, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 60}
+	intervalLength := valid
+	intervalLength.IntervalLength = 0
+	percent := valid
+	percent.TargetServiceLevel = 80
+	full := valid
+	full.TargetServiceLevel = 1
+	for _, params := range []FteParams{intervalLength, percent, full} {
+		res, err := GetNumberOfAgentsContext(context.Background(), params)
+		var rowErr *RowError
+		var fieldErr *FieldError
+		if !errors.As(err, &rowErr) || rowErr.Index != 4 || !errors.As(err, &fieldErr) {
+			t.Errorf("params %+v should be a row error, got %v", params, err)
+		}
+		if res != getEmptyResult(params) {
+			t.Errorf("result of invalid params should be empty, got %+v", res)
+		}
+	}
+
+	in := make(chan FteParams, 2)
+	in <- intervalLength
+	in <- valid
+	close(in)
+	var results []StreamResult
+	for res := range CalculateFteStream(context.Background(), in) {
+		results = append(results, res)
+	}
+	var rowErr *RowError
+	if len(results) != 2 || !errors.As(results[0].Err, &rowErr) || results[1].Err != nil {
+		t.Errorf("stream should report the invalid row and calculate the valid one, got %+v", results)
+	}
+}
+
+func TestCalculateFteContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	RegisterStrategy("cancel", func(ctx context.Context, calculator *Calculator, fteParams FteParams) (FteResult, error) {
+		cancel()
+		return FteResult{}, ctx.Err()
+	})
+	defer func() {
+		strategiesMutex.Lock()
+		delete(strategies, "cancel")
+		strategiesMutex.Unlock()
+	}()
+
+	params := []FteParams{
+		{ID: "1", Index: 0, Volume: 10, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 60, Engine: EngineFloat},
+		{ID: "1", Index: 1, Volume: 10, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 60, Channel: "cancel"},
+		{ID: "1", Index: 2, Volume: 10, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 60, Engine: EngineFloat},
+	}
+	fte, err := CalculateFteContext(ctx, params)
+	var unfinished *UnfinishedError
+	if !errors.As(err, &unfinished) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("error should be unfinished rows, got %v", err)
+	}
+	if len(unfinished.Rows) != 2 || unfinished.Rows[0] != 1 || unfinished.Rows[1] != 2 {
+		t.Errorf("rows 1 and 2 should be unfinished, got %v", unfinished.Rows)
+	}
+	if fte[0].Volume == 0 || fte[2].Volume != 0 || fte[2].Index != 2 {
+		t.Errorf("only first row should be calculated, got %+v", fte)
+	}
+}
@@ -0,0 +1,366 @@
+// Package csvcodec reads and writes FteParams and FteResult as CSV.
+//
+// Columns are matched to struct fields by name, case insensitively, unless they are mapped with
+// WithColumn. Values of the file can be in other units than the fields, WithScale sets the factor
+// from the file to the field, e.g. Minutes for AHT in minutes or Percent for service level as 80.
+//
+//	r := csvcodec.NewParamsReader(file,
+//		csvcodec.WithColumn("calls", "Volume"),
+//		csvcodec.WithColumn("aht_min", "Aht"),
+//		csvcodec.WithColumn("sl_target", "TargetServiceLevel"),
+//		csvcodec.WithScale("Aht", csvcodec.Minutes),
+//		csvcodec.WithScale("TargetServiceLevel", csvcodec.Percent),
+//	)
+//	params, err := r.ReadAll()
+package csvcodec
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+)
+
+const (
+	// Minutes - scale of durations in minutes
+	Minutes = 60
+	// Hours - scale of durations in hours
+	Hours = 3600
+	// Percent - scale of ratios in percents
+	Percent = 0.01
+)
+
+// Option - configuration of a reader or writer
+type Option func(*config)
+
+type config struct {
+	columns       map[string]string
+	headers       map[string]string
+	scales        map[string]float64
+	ignoreUnknown bool
+	comma         rune
+}
+
+// WithColumn maps column header to field of FteParams or FteResult, the header is used when writing the field
+func WithColumn(header string, field string) Option {
+	return func(c *config) {
+		c.columns[strings.ToLower(header)] = field
+		c.headers[strings.ToLower(field)] = header
+	}
+}
+
+// WithScale sets factor converting values of the file into values of the field,
+// e.g. Minutes when the file has AHT in minutes. Written values are divided by it. Scaled values of
+// integer fields are rounded, without a scale they must be whole numbers.
+func WithScale(field string, scale float64) Option {
+	return func(c *config) {
+		c.scales[strings.ToLower(field)] = scale
+	}
+}
+
+// WithIgnoreUnknown makes readers skip columns not matching any field instead of failing
+func WithIgnoreUnknown() Option {
+	return func(c *config) {
+		c.ignoreUnknown = true
+	}
+}
+
+// WithComma sets field delimiter, ',' when not set
+func WithComma(comma rune) Option {
+	return func(c *config) {
+		c.comma = comma
+	}
+}
+
+func newConfig(options []Option) *config {
+	c := &config{
+		columns: make(map[string]string),
+		headers: make(map[string]string),
+		scales:  make(map[string]float64),
+		comma:   ',',
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// field - CSV column of a struct field
+type field struct {
+	name   string
+	header string
+	index  int
+	scale  float64
+}
+
+// getFields returns all fields of the struct type in declaration order
+func (c *config) getFields(t reflect.Type) []field {
+	fields := make([]field, t.NumField())
+	for i := range fields {
+		name := t.Field(i).Name
+		fields[i] = field{name: name, header: name, index: i, scale: 1}
+		if header, ok := c.headers[strings.ToLower(name)]; ok {
+			fields[i].header = header
+		}
+		if scale, ok := c.scales[strings.ToLower(name)]; ok {
+			fields[i].scale = scale
+		}
+	}
+	return fields
+}
+
+// check returns an error when options name fields the struct type doesn't have
+func (c *config) check(t reflect.Type) error {
+	known := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		known[strings.ToLower(t.Field(i).Name)] = true
+	}
+	for header, name := range c.columns {
+		if !known[strings.ToLower(name)] {
+			return fmt.Errorf("csvcodec: column %q is mapped to unknown field %s", header, name)
+		}
+	}
+	for name, scale := range c.scales {
+		if !known[name] {
+			return fmt.Errorf("csvcodec: scale of unknown field %s", name)
+		}
+		if scale == 0 || math.IsInf(scale, 0) || math.IsNaN(scale) {
+			return fmt.Errorf("csvcodec: invalid scale %v of field %s", scale, name)
+		}
+	}
+	return nil
+}
+
+// decoder - reader of rows of a struct type
+type decoder struct {
+	config  *config
+	t       reflect.Type
+	reader  *csv.Reader
+	columns []*field
+	row     int
+	err     error
+}
+
+func newDecoder(r io.Reader, t reflect.Type, options []Option) *decoder {
+	d := &decoder{config: newConfig(options), t: t, reader: csv.NewReader(r)}
+	d.reader.Comma = d.config.comma
+	d.reader.FieldsPerRecord = -1
+	d.reader.TrimLeadingSpace = true
+	return d
+}
+
+func (d *decoder) readHeader() error {
+	if err := d.config.check(d.t); err != nil {
+		return err
+	}
+	header, err := d.reader.Read()
+	if err == io.EOF {
+		return errors.New("csvcodec: missing header")
+	}
+	if err != nil {
+		return err
+	}
+	fields := d.config.getFields(d.t)
+	byName := make(map[string]*field)
+	for i := range fields {
+		byName[strings.ToLower(fields[i].name)] = &fields[i]
+	}
+	seen := make(map[string]string)
+	d.columns = make([]*field, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		name := column
+		if mapped, ok := d.config.columns[strings.ToLower(column)]; ok {
+			name = mapped
+		}
+		f, ok := byName[strings.ToLower(name)]
+		if !ok {
+			if d.config.ignoreUnknown {
+				continue
+			}
+			return fmt.Errorf("csvcodec: unknown column %q", column)
+		}
+		if previous, ok := seen[f.name]; ok {
+			return fmt.Errorf("csvcodec: columns %q and %q are both %s", previous, column, f.name)
+		}
+		seen[f.name] = column
+		d.columns[i] = f
+	}
+	return nil
+}
+
+// read decodes the next row into dst, a pointer to the struct
+func (d *decoder) read(dst reflect.Value) error {
+	if d.err != nil {
+		return d.err
+	}
+	if d.columns == nil {
+		if d.err = d.readHeader(); d.err != nil {
+			return d.err
+		}
+	}
+	record, err := d.reader.Read()
+	row := d.row
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			d.row++
+			return &erlangc.RowError{Row: row, Err: err}
+		}
+		d.err = err
+		return err
+	}
+	d.row++
+
+	value := dst.Elem()
+	var errs []error
+	if len(record) != len(d.columns) {
+		errs = append(errs, fmt.Errorf("got %d columns, header has %d", len(record), len(d.columns)))
+	}
+	for i, cell := range record {
+		if i >= len(d.columns) || d.columns[i] == nil {
+			continue
+		}
+		if err := setField(value.Field(d.columns[i].index), d.columns[i], strings.TrimSpace(cell)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return &erlangc.RowError{Row: row, ID: value.FieldByName("ID").String(), Index: value.FieldByName("Index").Int(), Err: errors.Join(errs...)}
+	}
+	return nil
+}
+
+func setField(dst reflect.Value, f *field, cell string) error {
+	if cell == "" {
+		return nil
+	}
+	fail := func(reason string) error {
+		return &erlangc.FieldError{Field: f.name, Value: cell, Reason: fmt.Sprintf("column %s: %s", f.header, reason)}
+	}
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return fail("not a boolean")
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		if i, err := strconv.ParseInt(cell, 10, 64); err == nil && f.scale == 1 {
+			dst.SetInt(i)
+			return nil
+		}
+		x, err := strconv.ParseFloat(cell, 64)
+		if err != nil || math.IsInf(x, 0) || math.IsNaN(x) {
+			return fail("not a number")
+		}
+		if f.scale == 1 && x != math.Trunc(x) {
+			return fail("not a whole number")
+		}
+		// scaled fields like AHT in minutes are rounded to the nearest second
+		dst.SetInt(int64(math.Round(x * f.scale)))
+	case reflect.Float64:
+		x, err := strconv.ParseFloat(cell, 64)
+		if err != nil {
+			return fail("not a number")
+		}
+		dst.SetFloat(x * f.scale)
+	default:
+		return fail("unsupported type " + dst.Type().String())
+	}
+	return nil
+}
+
+// readAll reads rows until EOF and passes them to add, rows with errors are skipped
+// and their *erlangc.RowError are joined
+func (d *decoder) readAll(add func(reflect.Value)) error {
+	var errs []error
+	for {
+		dst := reflect.New(d.t)
+		err := d.read(dst)
+		if err == io.EOF {
+			return errors.Join(errs...)
+		}
+		var rowErr *erlangc.RowError
+		switch {
+		case err == nil:
+			add(dst.Elem())
+		case errors.As(err, &rowErr):
+			errs = append(errs, err)
+		default:
+			return errors.Join(append(errs, err)...)
+		}
+	}
+}
+
+// encoder - writer of rows of a struct type
+type encoder struct {
+	config *config
+	t      reflect.Type
+	writer *csv.Writer
+	fields []field
+}
+
+func newEncoder(w io.Writer, t reflect.Type, options []Option) *encoder {
+	e := &encoder{config: newConfig(options), t: t, writer: csv.NewWriter(w)}
+	e.writer.Comma = e.config.comma
+	return e
+}
+
+// writeHeader writes the header unless it is already written
+func (e *encoder) writeHeader() error {
+	if e.fields != nil {
+		return nil
+	}
+	if err := e.config.check(e.t); err != nil {
+		return err
+	}
+	e.fields = e.config.getFields(e.t)
+	header := make([]string, len(e.fields))
+	for i, f := range e.fields {
+		header[i] = f.header
+	}
+	return e.writer.Write(header)
+}
+
+// write encodes src, the struct, writing the header before the first row
+func (e *encoder) write(src reflect.Value) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	record := make([]string, len(e.fields))
+	for i, f := range e.fields {
+		record[i] = formatField(src.Field(f.index), f.scale)
+	}
+	return e.writer.Write(record)
+}
+
+func formatField(src reflect.Value, scale float64) string {
+	switch src.Kind() {
+	case reflect.String:
+		return src.String()
+	case reflect.Bool:
+		return strconv.FormatBool(src.Bool())
+	case reflect.Int, reflect.Int64:
+		if scale == 1 {
+			return strconv.FormatInt(src.Int(), 10)
+		}
+		return strconv.FormatFloat(float64(src.Int())/scale, 'f', -1, 64)
+	case reflect.Float64:
+		return strconv.FormatFloat(src.Float()/scale, 'f', -1, 64)
+	}
+	return fmt.Sprint(src.Interface())
+}
+
+func (e *encoder) flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
@@ -0,0 +1,166 @@
+package csvcodec
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+)
+
+var forecastOptions = []Option{
+	WithColumn("queue", "ID"),
+	WithColumn("calls", "Volume"),
+	WithColumn("aht_min", "Aht"),
+	WithColumn("sl_target", "TargetServiceLevel"),
+	WithColumn("sl_seconds", "TargetTime"),
+	WithScale("Aht", Minutes),
+	WithScale("TargetServiceLevel", Percent),
+}
+
+func TestParamsReader(t *testing.T) {
+	input := `queue,Index,calls,aht_min,sl_target,sl_seconds,IntervalLength
+sales,0,500,5,80,20,900
+sales,1,250,4.5,80,20,900
+`
+	params, err := NewParamsReader(strings.NewReader(input), forecastOptions...).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []erlangc.FteParams{
+		{ID: "sales", Index: 0, Volume: 500, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 20, IntervalLength: 900},
+		{ID: "sales", Index: 1, Volume: 250, Aht: 270, TargetServiceLevel: 0.8, TargetTime: 20, IntervalLength: 900},
+	}
+	if len(params) != len(expected) {
+		t.Fatalf("should read %d rows, got %d", len(expected), len(params))
+	}
+	for i := range expected {
+		if params[i] != expected[i] {
+			t.Errorf("row %d should be %+v, got %+v", i, expected[i], params[i])
+		}
+	}
+
+	// without mapping columns are field names in any case
+	params, err = NewParamsReader(strings.NewReader("id,VOLUME,aht\na,1,300\n")).ReadAll()
+	if err != nil || len(params) != 1 || params[0].ID != "a" || params[0].Volume != 1 || params[0].Aht != 300 {
+		t.Errorf("should read field names, got %+v, %v", params, err)
+	}
+}
+
+func TestParamsReaderErrors(t *testing.T) {
+	input := `queue,Index,calls,aht_min,sl_target
+sales,0,500,5,80
+sales,1,many,x,80
+sales,2,250
+sales,3,250,5,80
+`
+	params, err := NewParamsReader(strings.NewReader(input), forecastOptions...).ReadAll()
+	if len(params) != 2 || params[0].Index != 0 || params[1].Index != 3 {
+		t.Errorf("should read valid rows 0 and 3, got %+v", params)
+	}
+	var rowErrs []*erlangc.RowError
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var rowErr *erlangc.RowError
+		if !errors.As(err, &rowErr) {
+			t.Fatalf("error should be *erlangc.RowError, got %v", err)
+		}
+		rowErrs = append(rowErrs, rowErr)
+	}
+	if len(rowErrs) != 2 || rowErrs[0].Row != 1 || rowErrs[0].Index != 1 || rowErrs[1].Row != 2 {
+		t.Fatalf("rows 1 and 2 should fail, got %v", err)
+	}
+	var fields []string
+	for _, err := range rowErrs[0].Err.(interface{ Unwrap() []error }).Unwrap() {
+		var fieldErr *erlangc.FieldError
+		if errors.As(err, &fieldErr) {
+			fields = append(fields, fieldErr.Field)
+		}
+	}
+	if strings.Join(fields, ",") != "Volume,Aht" {
+		t.Errorf("row 1 should fail on Volume and Aht, got %v", rowErrs[0].Err)
+	}
+
+	// reading continues after a failed row
+	r := NewParamsReader(strings.NewReader("id,volume\na,x\nb,1\n"))
+	if _, err := r.Read(); err == nil {
+		t.Error("invalid volume should fail")
+	}
+	if param, err := r.Read(); err != nil || param.ID != "b" {
+		t.Errorf("second row should be read, got %+v, %v", param, err)
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("should end with io.EOF, got %v", err)
+	}
+
+	// whole fields aren't rounded without a scale
+	params, err = NewParamsReader(strings.NewReader("id,aht\na,12.7\nb,300.0\n")).ReadAll()
+	if len(params) != 1 || params[0].ID != "b" || params[0].Aht != 300 {
+		t.Errorf("should read only row b, got %+v", params)
+	}
+	var fieldErr *erlangc.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Aht" || fieldErr.Value != "12.7" {
+		t.Errorf("aht 12.7 should fail, got %v", err)
+	}
+
+	headerErrs := []struct {
+		input   string
+		options []Option
+	}{
+		{"calls,unknown\n1,2\n", nil},
+		{"calls,Volume\n1,2\n", []Option{WithColumn("calls", "Volume")}},
+		{"calls\n1\n", []Option{WithColumn("calls", "Volumes")}},
+		{"Volume\n1\n", []Option{WithScale("Volume", 0)}},
+		{"", nil},
+	}
+	for _, test := range headerErrs {
+		_, err := NewParamsReader(strings.NewReader(test.input), test.options...).ReadAll()
+		var rowErr *erlangc.RowError
+		if err == nil || errors.As(err, &rowErr) {
+			t.Errorf("header %q should fail, got %v", test.input, err)
+		}
+	}
+	params, err = NewParamsReader(strings.NewReader("calls,unknown\n1,2\n"), WithColumn("calls", "Volume"), WithIgnoreUnknown()).ReadAll()
+	if err != nil || len(params) != 1 || params[0].Volume != 1 {
+		t.Errorf("unknown columns should be ignored, got %+v, %v", params, err)
+	}
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	params := []erlangc.FteParams{
+		{ID: "sales", Index: 0, Volume: 500, Aht: 270, TargetServiceLevel: 0.8, TargetTime: 20, IntervalLength: 900, Channel: "voice"},
+	}
+	var buf bytes.Buffer
+	if err := NewParamsWriter(&buf, forecastOptions...).WriteAll(params); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "queue,Index,Timestamp,calls,IntervalLength,aht_min,sl_target,sl_seconds") ||
+		!strings.Contains(buf.String(), "sales,0,0,500,900,4.5,80,20") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+	read, err := NewParamsReader(&buf, forecastOptions...).ReadAll()
+	if err != nil || len(read) != 1 || read[0] != params[0] {
+		t.Errorf("params should survive a round trip, got %+v, %v", read, err)
+	}
+
+	fte := erlangc.CalculateFte(params)
+	buf.Reset()
+	options := []Option{WithScale("ServiceLevel", Percent), WithScale("Occupancy", Percent), WithComma(';')}
+	if err := NewResultWriter(&buf, options...).WriteAll(fte); err != nil {
+		t.Fatal(err)
+	}
+	results, err := NewResultReader(&buf, options...).ReadAll()
+	if err != nil || len(results) != 1 {
+		t.Fatalf("should read 1 result, got %+v, %v", results, err)
+	}
+	if results[0].Volume != fte[0].Volume || results[0].Constraint != fte[0].Constraint ||
+		results[0].ServiceLevel-fte[0].ServiceLevel > 1e-12 || fte[0].ServiceLevel-results[0].ServiceLevel > 1e-12 {
+		t.Errorf("result should survive a round trip, expected %+v, got %+v", fte[0], results[0])
+	}
+
+	buf.Reset()
+	if err := NewResultWriter(&buf).WriteAll(nil); err != nil || !strings.HasPrefix(buf.String(), "ID,Index") {
+		t.Errorf("header should be written without rows, got %q, %v", buf.String(), err)
+	}
+}
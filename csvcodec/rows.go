@@ -0,0 +1,126 @@
+package csvcodec
+
+import (
+	"io"
+	"reflect"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+)
+
+var (
+	paramsType = reflect.TypeOf(erlangc.FteParams{})
+	resultType = reflect.TypeOf(erlangc.FteResult{})
+)
+
+// ParamsReader - reader of FteParams rows
+type ParamsReader struct {
+	decoder *decoder
+}
+
+func NewParamsReader(r io.Reader, options ...Option) *ParamsReader {
+	return &ParamsReader{decoder: newDecoder(r, paramsType, options)}
+}
+
+// Read reads the next row, it returns io.EOF after the last row. Invalid rows are returned as
+// *erlangc.RowError wrapping *erlangc.FieldError of every invalid cell, reading can continue after them.
+func (r *ParamsReader) Read() (erlangc.FteParams, error) {
+	var params erlangc.FteParams
+	err := r.decoder.read(reflect.ValueOf(&params))
+	return params, err
+}
+
+// ReadAll reads all remaining rows, invalid rows are left out and their errors are joined
+func (r *ParamsReader) ReadAll() ([]erlangc.FteParams, error) {
+	var params []erlangc.FteParams
+	err := r.decoder.readAll(func(row reflect.Value) {
+		params = append(params, row.Interface().(erlangc.FteParams))
+	})
+	return params, err
+}
+
+// ResultReader - reader of FteResult rows
+type ResultReader struct {
+	decoder *decoder
+}
+
+func NewResultReader(r io.Reader, options ...Option) *ResultReader {
+	return &ResultReader{decoder: newDecoder(r, resultType, options)}
+}
+
+// Read reads the next row like ParamsReader.Read
+func (r *ResultReader) Read() (erlangc.FteResult, error) {
+	var result erlangc.FteResult
+	err := r.decoder.read(reflect.ValueOf(&result))
+	return result, err
+}
+
+// ReadAll reads all remaining rows like ParamsReader.ReadAll
+func (r *ResultReader) ReadAll() ([]erlangc.FteResult, error) {
+	var fte []erlangc.FteResult
+	err := r.decoder.readAll(func(row reflect.Value) {
+		fte = append(fte, row.Interface().(erlangc.FteResult))
+	})
+	return fte, err
+}
+
+// ParamsWriter - writer of FteParams rows with a column of every field
+type ParamsWriter struct {
+	encoder *encoder
+}
+
+func NewParamsWriter(w io.Writer, options ...Option) *ParamsWriter {
+	return &ParamsWriter{encoder: newEncoder(w, paramsType, options)}
+}
+
+// Write writes a row, the header is written before the first one. Rows are buffered until Flush.
+func (w *ParamsWriter) Write(params erlangc.FteParams) error {
+	return w.encoder.write(reflect.ValueOf(params))
+}
+
+// WriteAll writes the header and all rows and flushes them
+func (w *ParamsWriter) WriteAll(params []erlangc.FteParams) error {
+	if err := w.encoder.writeHeader(); err != nil {
+		return err
+	}
+	for _, param := range params {
+		if err := w.Write(param); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func (w *ParamsWriter) Flush() error {
+	return w.encoder.flush()
+}
+
+// ResultWriter - writer of FteResult rows with a column of every field
+type ResultWriter struct {
+	encoder *encoder
+}
+
+func NewResultWriter(w io.Writer, options ...Option) *ResultWriter {
+	return &ResultWriter{encoder: newEncoder(w, resultType, options)}
+}
+
+// Write writes a row like ParamsWriter.Write
+func (w *ResultWriter) Write(result erlangc.FteResult) error {
+	return w.encoder.write(reflect.ValueOf(result))
+}
+
+// WriteAll writes the header and all rows and flushes them
+func (w *ResultWriter) WriteAll(fte []erlangc.FteResult) error {
+	if err := w.encoder.writeHeader(); err != nil {
+		return err
+	}
+	for _, result := range fte {
+		if err := w.Write(result); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func (w *ResultWriter) Flush() error {
+	return w.encoder.flush()
+}
@@ -0,0 +1,129 @@
+package erlangc
+
+import (
+	"context"
+	"math"
+
+	big "github.com/Tymeshift/erlang-c-go/internal/bignum"
+)
+
+// Engine - numeric implementation of the Erlang C formula
+type Engine int
+
+const (
+	// EngineDefault uses the engine of the calculator, EngineExact for package functions
+	EngineDefault Engine = iota
+	// EngineExact evaluates Erlang C with big rationals
+	EngineExact
+	// EngineFloat evaluates Erlang C in float64 with the Erlang B recurrence, stable for thousands of agents
+	EngineFloat
+	// EngineApprox approximates Erlang C with the Halfin-Whitt formula in constant time, for fast what-if answers
+	EngineApprox
+)
+
+func getErlangCFloat(intensity float64, agents int64) float64 {
+	blocking := getErlangB(intensity, agents)
+	occupancy := intensity / float64(agents)
+	return blocking / (1 - occupancy*(1-blocking))
+}
+
+// exactKpisMargin - number of square roots of intensity above it from which KPIs of the exact engine are
+// calculated with the float engine
+const exactKpisMargin = 10
+
+// getEngineKpis returns probability of wait, service level and average speed of answer calculated with the engine,
+// it stops with ctx.Err() when ctx is done
+func (c *Calculator) getEngineKpis(ctx context.Context, engine Engine, intensity float64, agents int64, targetTime int64, aht int64) (float64, float64, float64, error) {
+	if engine == EngineDefault {
+		engine = c.engine
+	}
+	// far above the intensity probability of wait is negligible, the float engine tells it as well
+	// without exact terms for every agent of a headcount raised by min staffing
+	if engine == EngineExact && float64(agents) > intensity+exactKpisMargin*(math.Sqrt(intensity)+1) {
+		engine = EngineFloat
+	}
+	evaluator, err := c.newErlangCEvaluator(ctx, engine, intensity, agents)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	erlangC := evaluator.erlangC()
+	serviceLevel := getServiceLevel(erlangC, intensity, agents, targetTime, aht)
+	asa := erlangC * float64(aht) / (float64(agents) - intensity)
+	return erlangC, serviceLevel, asa, nil
+}
+
+// erlangCEvaluator evaluates Erlang C for a number of agents and moves on to the next number of agents
+// reusing terms of the previous one, so the search for agents doesn't start over for every candidate
+type erlangCEvaluator interface {
+	erlangC() float64
+	next()
+}
+
+// newErlangCEvaluator returns evaluator of the engine starting at agents, building the exact one stops with ctx.Err()
+// when ctx is done
+func (c *Calculator) newErlangCEvaluator(ctx context.Context, engine Engine, intensity float64, agents int64) (erlangCEvaluator, error) {
+	if engine == EngineDefault {
+		engine = c.engine
+	}
+	switch engine {
+	case EngineFloat:
+		return &floatErlangC{intensity: intensity, agents: agents, blocking: getErlangB(intensity, agents)}, nil
+	case EngineApprox:
+		return &approxErlangC{intensity: intensity, agents: agents}, nil
+	}
+	return newExactErlangC(ctx, c.getFactorialCache(), intensity, agents)
+}
+
+type exactErlangC struct {
+	intensity    float64
+	bigIntensity *big.Rat
+	agents       int64
+	// intensity^agents / agents!
+	term *big.Rat
+	// sum of intensity^i / i! for i < agents
+	sum *big.Rat
+}
+
+func newExactErlangC(ctx context.Context, cache FactorialCache, intensity float64, agents int64) (*exactErlangC, error) {
+	bigIntensity := new(big.Rat).SetFloat64(intensity)
+	sum, err := getYContext(ctx, bigIntensity, agents)
+	if err != nil {
+		return nil, err
+	}
+	AN := getAN(bigIntensity, big.NewInt(agents))
+	return &exactErlangC{
+		intensity:    intensity,
+		bigIntensity: bigIntensity,
+		agents:       agents,
+		term:         new(big.Rat).Quo(AN, new(big.Rat).SetInt(getCachedFactorial(cache, agents))),
+		sum:          sum,
+	}, nil
+}
+
+func (e *exactErlangC) erlangC() float64 {
+	X := new(big.Rat).Mul(e.term, new(big.Rat).SetFloat64(getAgentsCoeff(e.intensity, e.agents)))
+	return getPW(X, new(big.Rat).Set(e.sum))
+}
+
+func (e *exactErlangC) next() {
+	e.sum.Add(e.sum, e.term)
+	e.agents++
+	e.term.Mul(e.term, e.bigIntensity)
+	e.term.Quo(e.term, new(big.Rat).SetInt64(e.agents))
+}
+
+type floatErlangC struct {
+	intensity float64
+	agents    int64
+	blocking  float64
+}
+
+func (e *floatErlangC) erlangC() float64 {
+	occupancy := e.intensity / float64(e.agents)
+	return e.blocking / (1 - occupancy*(1-e.blocking))
+}
+
+func (e *floatErlangC) next() {
+	e.agents++
+	e.blocking = nextErlangB(e.intensity, e.agents, e.blocking)
+}
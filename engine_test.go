@@ -0,0 +1,134 @@
+package erlangc
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+
+	big "github.com/Tymeshift/erlang-c-go/internal/bignum"
+)
+
+func readFteParams(t testing.TB) []FteParams {
+	bytes, err := os.ReadFile("fteParams.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var params []FteParams
+	if err := json.Unmarshal(bytes, &params); err != nil {
+		t.Fatal(err)
+	}
+	return params
+}
+
+func TestGetErlangCFloat(t *testing.T) {
+	res := getErlangCFloat(8, 10)
+	expected := 0.40918
+	if math.Round(res*100000)/100000 != expected {
+		t.Errorf("erlang should be %f, got %f", expected, res)
+	}
+
+	// exact erlang of 2700 agents for 2606.3 erlangs, precomputed with getErlangC
+	intensity := 2606.3
+	agents := int64(2700)
+	exact := 0.041569867554
+	res = getErlangCFloat(intensity, agents)
+	if math.Abs(res-exact) > 1e-4 {
+		t.Errorf("erlang of %d agents should be %f, got %f", agents, exact, res)
+	}
+}
+
+func TestEngineFloatMatchesExact(t *testing.T) {
+	params := readFteParams(t)
+	for i := range params {
+		exact := GetNumberOfAgents(params[i])
+		params[i].Engine = EngineFloat
+		res := GetNumberOfAgents(params[i])
+		if res.Volume != exact.Volume {
+			t.Errorf("agents of row %d should be %d, got %d", i, exact.Volume, res.Volume)
+		}
+		if math.Abs(res.ServiceLevel-exact.ServiceLevel) > 1e-4 || math.Abs(res.WaitProbability-exact.WaitProbability) > 1e-4 {
+			t.Errorf("KPIs of row %d should be %+v, got %+v", i, exact, res)
+		}
+	}
+}
+
+func BenchmarkCaclulateFTEFloat(b *testing.B) {
+	params := readFteParams(b)
+	for i := range params {
+		params[i].Engine = EngineFloat
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CalculateFte(params)
+	}
+}
+
+func TestErlangCEvaluator(t *testing.T) {
+	intensity := 33.33
+	agents := int64(34)
+	exact, _ := defaultCalculator.newErlangCEvaluator(context.Background(), EngineExact, intensity, agents)
+	float, _ := defaultCalculator.newErlangCEvaluator(context.Background(), EngineFloat, intensity, agents)
+	for ; agents < 60; agents++ {
+		AN := getAN(new(big.Rat).SetFloat64(intensity), big.NewInt(agents))
+		expected := getErlangC(AN, getFactorialSwing(agents), intensity, agents)
+		if res := exact.erlangC(); res != expected {
+			t.Errorf("exact erlang of %d agents should be %f, got %f", agents, expected, res)
+		}
+		expected = getErlangCFloat(intensity, agents)
+		if res := float.erlangC(); math.Abs(res-expected) > 1e-12 {
+			t.Errorf("float erlang of %d agents should be %f, got %f", agents, expected, res)
+		}
+		exact.next()
+		float.next()
+	}
+}
+
+func benchmarkGetNumberOfAgents(b *testing.B, engine Engine, volume float64) {
+	params := FteParams{
+		ID:                 "1",
+		Volume:             volume,
+		IntervalLength:     900,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+		Engine:             engine,
+	}
+	for i := 0; i < b.N; i++ {
+		GetNumberOfAgents(params)
+	}
+}
+
+func BenchmarkGetNumberOfAgentsExact500(b *testing.B) {
+	benchmarkGetNumberOfAgents(b, EngineExact, 500)
+}
+
+// 5000 erlangs need more agents than DefaultFactorialCacheSize
+func BenchmarkGetNumberOfAgentsExact15000(b *testing.B) {
+	benchmarkGetNumberOfAgents(b, EngineExact, 15000)
+}
+
+func BenchmarkGetNumberOfAgentsFloat500(b *testing.B) {
+	benchmarkGetNumberOfAgents(b, EngineFloat, 500)
+}
+
+func BenchmarkGetNumberOfAgentsFloat5000(b *testing.B) {
+	benchmarkGetNumberOfAgents(b, EngineFloat, 5000)
+}
+
+// BenchmarkCaclulateFTELarge calculates every 16th row of fteParams.json with 10 times the volume,
+// hundreds to thousands of agents per row
+func BenchmarkCaclulateFTELarge(b *testing.B) {
+	var params []FteParams
+	for i, param := range readFteParams(b) {
+		if i%16 == 0 {
+			param.Volume *= 10
+			params = append(params, param)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CalculateFte(params)
+	}
+}
@@ -0,0 +1,140 @@
+package erlangc
+
+import (
+	"context"
+	"math"
+)
+
+const (
+	erlangAMaxQueue = 100000
+	erlangAEpsilon  = 1e-12
+)
+
+// ErlangA - service metrics of a queue with caller abandonment (M/M/c+M)
+//
+// ServiceLevel - share of arrivals answered within the target time
+// Abandonment - share of arrivals that hang up before being answered
+// Asa - average speed of answer of the answered calls in seconds
+// WaitProbability - share of arrivals finding all agents busy
+type ErlangA struct {
+	ServiceLevel    float64
+	Abandonment     float64
+	Asa             float64
+	WaitProbability float64
+}
+
+// getAnswerProbabilities returns, for every number of callers queued ahead,
+// the probability that a caller with exponentially distributed patience is
+// answered within targetTime. The time to answer is a sum of exponential
+// phases, which is evaluated with uniformization.
+func getAnswerProbabilities(queueLen int, agents int64, mu float64, theta float64, targetTime int64) []float64 {
+	answered := make([]float64, queueLen)
+	if targetTime <= 0 {
+		return answered
+	}
+	rate := func(k int) float64 {
+		return float64(agents)*mu + float64(k+1)*theta
+	}
+	uniform := rate(queueLen - 1)
+	mean := uniform * float64(targetTime)
+	steps := int(math.Ceil(mean + 10*math.Sqrt(mean) + 10))
+	within := make([]float64, queueLen)
+	for n := 0; n <= steps; n++ {
+		lg, _ := math.Lgamma(float64(n + 1))
+		weight := math.Exp(float64(n)*math.Log(mean) - mean - lg)
+		for k := range answered {
+			answered[k] += weight * within[k]
+		}
+		for k := queueLen - 1; k >= 0; k-- {
+			next := 1.0
+			if k > 0 {
+				next = within[k-1]
+			}
+			p := rate(k) / uniform
+			within[k] = p*next + (1-p)*within[k]
+		}
+	}
+	return answered
+}
+
+// GetErlangA calculates service level, abandonment rate and average speed of answer
+// of the given number of agents when callers abandon after an exponentially distributed patience
+//
+// intensity - traffic intensity in erlangs
+// agents - number of agents
+// targetTime - target answer time, acceptable wait time in seconds
+// aht - average handle time in seconds
+// patience - average time callers are willing to wait in seconds
+func GetErlangA(intensity float64, agents int64, targetTime int64, aht int64, patience int64) ErlangA {
+	if intensity <= 0 {
+		return ErlangA{ServiceLevel: 1}
+	}
+	if agents <= 0 {
+		return ErlangA{Abandonment: 1}
+	}
+	mu := 1 / float64(aht)
+	theta := 1 / float64(patience)
+	lambda := intensity * mu
+
+	// probabilities of finding j callers queued, relative to all agents being busy
+	queue := []float64{1}
+	queued := 1.0
+	for j := 1; j < erlangAMaxQueue; j++ {
+		q := queue[j-1] * lambda / (float64(agents)*mu + float64(j)*theta)
+		queue = append(queue, q)
+		queued += q
+		if q < erlangAEpsilon*queued {
+			break
+		}
+	}
+	blocking := getErlangB(intensity, agents)
+	if blocking == 0 {
+		return ErlangA{ServiceLevel: 1}
+	}
+	immediate := 1/blocking - 1
+	norm := immediate + queued
+
+	answerProbabilities := getAnswerProbabilities(len(queue), agents, mu, theta, targetTime)
+	reach := 1.0
+	sojourn := 0.0
+	served := immediate
+	withinTarget := immediate
+	wait := 0.0
+	for j, q := range queue {
+		r := float64(agents)*mu + float64(j)*theta
+		reach *= r / (r + theta)
+		sojourn += 1 / (r + theta)
+		served += q * reach
+		withinTarget += q * reach * answerProbabilities[j]
+		wait += q * reach * sojourn
+	}
+
+	return ErlangA{
+		ServiceLevel:    withinTarget / norm,
+		Abandonment:     1 - served/norm,
+		Asa:             wait / served,
+		WaitProbability: queued / norm,
+	}
+}
+
+func getAgentsWithErlangA(ctx context.Context, fteParams FteParams) (float64, float64, Constraint, error) {
+	intensity := getIntensity(fteParams.Volume, fteParams.Aht, fteParams.IntervalLength)
+	// answered calls can't exceed agent capacity, so service level is at most agents / intensity
+	agents := math.Max(1, math.Ceil(intensity*fteParams.TargetServiceLevel))
+
+	constraint := ConstraintServiceLevel
+	for {
+		if err := ctx.Err(); err != nil {
+			return intensity, agents, constraint, err
+		}
+		erlangA := GetErlangA(intensity, int64(agents), fteParams.TargetTime, fteParams.Aht, fteParams.Patience)
+		missed := getMissedTarget(fteParams, erlangA.ServiceLevel, erlangA.Asa)
+		if missed == "" {
+			break
+		}
+		constraint = missed
+		agents++
+	}
+
+	return intensity, agents, constraint, nil
+}
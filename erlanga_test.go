@@ -0,0 +1,67 @@
+package erlangc
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestGetErlangA(t *testing.T) {
+	// with almost infinite patience Erlang A converges to Erlang C
+	res := GetErlangA(8, 10, 1000, 1500, 1000000000)
+	expected := 0.89214
+	if math.Abs(res.ServiceLevel-expected) > 0.0001 {
+		t.Errorf("service level should be %f, got %f", expected, res.ServiceLevel)
+	}
+	if res.Abandonment > 0.0001 {
+		t.Errorf("abandonment should be 0, got %f", res.Abandonment)
+	}
+	// ASA of Erlang C is erlangC * aht / (agents - intensity)
+	expected = 0.4091801508 * 1500 / 2
+	if math.Abs(res.Asa-expected) > 0.1 {
+		t.Errorf("ASA should be %f, got %f", expected, res.Asa)
+	}
+
+	patient := GetErlangA(8, 8, 20, 300, 600)
+	impatient := GetErlangA(8, 8, 20, 300, 60)
+	if impatient.Abandonment <= patient.Abandonment {
+		t.Errorf("abandonment should grow when patience drops, got %f and %f", patient.Abandonment, impatient.Abandonment)
+	}
+	if impatient.Asa >= patient.Asa {
+		t.Errorf("ASA should drop when patience drops, got %f and %f", patient.Asa, impatient.Asa)
+	}
+
+	res = GetErlangA(0, 1, 20, 300, 60)
+	if res.ServiceLevel != 1 {
+		t.Errorf("service level without traffic should be 1, got %f", res.ServiceLevel)
+	}
+}
+
+func TestGetNumberOfAgentsErlangA(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Index:              0,
+		Volume:             500,
+		IntervalLength:     900,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         20,
+	}
+	erlangC := GetNumberOfAgents(params)
+
+	params.Patience = 60
+	erlangA := GetNumberOfAgents(params)
+	if erlangA.Volume >= erlangC.Volume {
+		t.Errorf("Erlang A should need less than %d agents, got %d", erlangC.Volume, erlangA.Volume)
+	}
+
+	intensity, agents, _, _ := getAgentsWithErlangA(context.Background(), params)
+	sl := GetErlangA(intensity, int64(agents), params.TargetTime, params.Aht, params.Patience).ServiceLevel
+	if sl < params.TargetServiceLevel {
+		t.Errorf("service level of %f agents should reach %f, got %f", agents, params.TargetServiceLevel, sl)
+	}
+	sl = GetErlangA(intensity, int64(agents)-1, params.TargetTime, params.Aht, params.Patience).ServiceLevel
+	if sl >= params.TargetServiceLevel {
+		t.Errorf("service level of %f agents should be below %f, got %f", agents-1, params.TargetServiceLevel, sl)
+	}
+}
@@ -0,0 +1,82 @@
+package erlangc
+
+// BlockingParams - parameters to calculate number of lines where calls are blocked instead of queued
+type BlockingParams struct {
+	ID             string
+	Index          int64
+	Timestamp      int64
+	Volume         float64
+	IntervalLength int64
+	Aht            int64
+	TargetBlocking float64
+}
+
+type BlockingResult struct {
+	ID        string
+	Index     int64
+	Timestamp int64
+	Servers   int64
+	Blocking  float64
+}
+
+func nextErlangB(intensity float64, servers int64, blocking float64) float64 {
+	return intensity * blocking / (float64(servers) + intensity*blocking)
+}
+
+func getErlangB(intensity float64, servers int64) float64 {
+	blocking := 1.0
+	for i := int64(1); i <= servers; i++ {
+		blocking = nextErlangB(intensity, i, blocking)
+	}
+	return blocking
+}
+
+// GetBlocking calculates probability of a call being blocked when all servers are busy
+//
+// volume - incoming number of arrivals per time interval
+// aht - average handle time in seconds
+// intervalLength - time interval in seconds
+// servers - number of trunks, lines or SIP channels
+func GetBlocking(volume float64, aht int64, intervalLength int64, servers int64) float64 {
+	if volume <= 0 || aht <= 0 {
+		return 0
+	}
+	return getErlangB(getIntensity(volume, aht, intervalLength), servers)
+}
+
+func GetNumberOfServers(blockingParams BlockingParams) BlockingResult {
+	servers := int64(0)
+	blocking := 0.0
+	if blockingParams.Volume > 0 && blockingParams.Aht > 0 {
+		intensity := getIntensity(blockingParams.Volume, blockingParams.Aht, blockingParams.IntervalLength)
+		blocking = 1
+		// blocking underflows to 0 eventually, so the loop ends even for a zero target
+		for blocking > blockingParams.TargetBlocking && blocking > 0 {
+			servers++
+			blocking = nextErlangB(intensity, servers, blocking)
+		}
+	}
+
+	return BlockingResult{
+		ID:        blockingParams.ID,
+		Index:     blockingParams.Index,
+		Timestamp: blockingParams.Timestamp,
+		Servers:   servers,
+		Blocking:  blocking,
+	}
+}
+
+// CalculateServers calculates number of servers needed to keep blocking probability under target for incoming volume of arrivals per time interval
+//
+// volume - incoming number of arrivals per time interval
+// intervalLength - time interval in seconds
+// aht - average handle time in seconds
+// targetBlocking - maximum share of blocked calls (0 < targetBlocking < 1)
+func CalculateServers(params []BlockingParams) []BlockingResult {
+	servers := make([]BlockingResult, len(params))
+	for i, param := range params {
+		servers[i] = GetNumberOfServers(param)
+	}
+
+	return servers
+}
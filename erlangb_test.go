@@ -0,0 +1,38 @@
+package erlangc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGetErlangB(t *testing.T) {
+	res := getErlangB(8, 10)
+	expected := 0.12166
+	if math.Round(res*100000)/100000 != expected {
+		t.Errorf("erlang b should be %f, got %f", expected, res)
+	}
+
+	res = GetBlocking(12, 600, 900, 10)
+	expected = 0.12168
+	if math.Round(res*100000)/100000 != expected {
+		t.Errorf("blocking should be %f, got %f", expected, res)
+	}
+}
+
+func TestCalculateServers(t *testing.T) {
+	res := CalculateServers([]BlockingParams{
+		{ID: "1", Index: 0, Volume: 12, IntervalLength: 900, Aht: 600, TargetBlocking: 0.01},
+		{ID: "1", Index: 1, Volume: 180, IntervalLength: 3600, Aht: 1200, TargetBlocking: 0.02},
+		{ID: "1", Index: 2, Volume: 0, IntervalLength: 900, Aht: 600, TargetBlocking: 0.01},
+	})
+
+	expected := []int64{15, 71, 0}
+	for i, r := range res {
+		if r.Servers != expected[i] {
+			t.Errorf("servers for index %d should be %d, got %d", r.Index, expected[i], r.Servers)
+		}
+		if r.Blocking > 0.02 {
+			t.Errorf("blocking for index %d should be under target, got %f", r.Index, r.Blocking)
+		}
+	}
+}
@@ -1,28 +1,34 @@
 package erlangc
 
 import (
+	"context"
 	"math"
 	"sync"
 
 	"github.com/Tymeshift/erlang-c-go/factorial"
-	big "github.com/ncw/gmp"
+	big "github.com/Tymeshift/erlang-c-go/internal/bignum"
 )
 
 // FteParams - parameters to calculate FTE
 type FteParams struct {
-	ID                 string
-	Index              int64
-	Timestamp          int64
-	Volume             float64
-	IntervalLength     int64
-	Aht                int64
-	TargetServiceLevel float64
-	TargetTime         int64
-	MaxOccupancy       float64
-	Shrinkage          float64
-	Channel            string
-	MinStaffing        int64
-	Concurrency        int64
+	ID                         string
+	Index                      int64
+	Timestamp                  int64
+	Volume                     float64
+	IntervalLength             int64
+	Aht                        int64
+	TargetServiceLevel         float64
+	TargetTime                 int64
+	TargetAsa                  int64
+	MaxOccupancy               float64
+	Shrinkage                  float64
+	Channel                    string
+	MinStaffing                int64
+	MinStaffingBeforeShrinkage bool
+	Concurrency                int64
+	ConcurrencyAhtInflation    float64
+	Patience                   int64
+	Engine                     Engine
 }
 
 type FteResult struct {
@@ -30,10 +36,35 @@ type FteResult struct {
 	Index     int64
 	Timestamp int64
 	Volume    int64
+
+	MinStaffingApplied bool
+
+	Intensity       float64
+	RawAgents       int64
+	ServiceLevel    float64
+	WaitProbability float64
+	Asa             float64
+	Abandonment     float64
+	Occupancy       float64
+	Constraint      Constraint
 }
 
-var factorailCache = make(map[int64]*big.Int)
-var factorailCacheMutex = &sync.RWMutex{}
+var factorialCache FactorialCache = NewLRUFactorialCache(DefaultFactorialCacheSize)
+var factorialCacheMutex = &sync.RWMutex{}
+
+// SetFactorialCache replaces the cache of factorials used by the exact engine
+func SetFactorialCache(cache FactorialCache) {
+	factorialCacheMutex.Lock()
+	factorialCache = cache
+	factorialCacheMutex.Unlock()
+}
+
+// GetFactorialCache returns the cache of factorials used by the exact engine
+func GetFactorialCache() FactorialCache {
+	factorialCacheMutex.RLock()
+	defer factorialCacheMutex.RUnlock()
+	return factorialCache
+}
 
 func ratioExp(x *big.Rat, y *big.Int) *big.Rat {
 	num := x.Num()
@@ -43,26 +74,22 @@ func ratioExp(x *big.Rat, y *big.Int) *big.Rat {
 	return new(big.Rat).SetFrac(num, denom)
 }
 
-func getFactorial(n int64) big.Int {
-	var fact big.Int
-	fact.MulRange(1, n)
-	return fact
+func getFactorial(n int64) *big.Int {
+	// gmp's MulRange doesn't initialize a zero Int, NewInt does
+	return big.NewInt(1).MulRange(1, n)
 }
 
 func getFactorialSwing(n int64) *big.Int {
-	factorailCacheMutex.RLock()
-	cache, ok := factorailCache[n]
-	factorailCacheMutex.RUnlock()
-	if ok {
-		return cache
+	return getCachedFactorial(GetFactorialCache(), n)
+}
+
+func getCachedFactorial(cache FactorialCache, n int64) *big.Int {
+	if fact, ok := cache.Get(n); ok {
+		return fact
 	}
-	fact := factorial.Factorial(uint64(n))
-	factStr := fact.String()
-	factInt, _ := new(big.Int).SetString(factStr, 10)
-	factorailCacheMutex.Lock()
-	factorailCache[n] = factInt
-	factorailCacheMutex.Unlock()
-	return factInt
+	fact := big.FromBig(factorial.Factorial(uint64(n)))
+	cache.Add(n, fact)
+	return fact
 }
 
 func getIntensity(volume float64, aht int64, intervalLength int64) float64 {
@@ -74,23 +101,53 @@ func getAN(intensity *big.Rat, agents *big.Int) *big.Rat {
 	return res
 }
 
+func getAgentsCoeff(intensity float64, agents int64) float64 {
+	return math.Round((float64(agents)/(float64(agents)-intensity))*10000) / 10000
+}
+
 func getX(AN *big.Rat, factorial *big.Int, intensity float64, agents int64) *big.Rat {
-	agentsCoeff := math.Round((float64(agents)/(float64(agents)-intensity))*10000) / 10000
+	agentsCoeff := getAgentsCoeff(intensity, agents)
 	res := new(big.Rat).Quo(AN, new(big.Rat).SetInt(factorial))
 	return new(big.Rat).Mul(res, new(big.Rat).SetFloat64(agentsCoeff))
 }
 
 func getY(intensity *big.Rat, agents int64) *big.Rat {
-	sum := new(big.Rat)
-	for i := int64(0); i < agents; i++ {
-		iFact := getFactorialSwing(i)
-		aPowI := ratioExp(intensity, big.NewInt(i))
-		div := new(big.Rat).Quo(aPowI, new(big.Rat).SetInt(iFact))
-		sum = div.Add(sum, div)
-	}
+	sum, _ := getYContext(context.Background(), intensity, agents)
 	return sum
 }
 
+// yTermsPerCheck - number of terms of Y summed between checks of the context
+const yTermsPerCheck = 64
+
+// getYContext returns Y like getY, it stops with ctx.Err() when ctx is done.
+// With intensity p / q the sum of terms up to i is num / (q^i * i!), so every step multiplies num
+// by q * i and adds p^i, carrying the power instead of computing powers and factorials from scratch
+// and keeping integers, as adding rationals reduces every partial sum.
+func getYContext(ctx context.Context, intensity *big.Rat, agents int64) (*big.Rat, error) {
+	p, q := intensity.Num(), intensity.Denom()
+	num := big.NewInt(0)
+	den := big.NewInt(1)
+	pow := big.NewInt(1)
+	i := big.NewInt(0)
+	for n := int64(0); n < agents; n++ {
+		if n%yTermsPerCheck == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if n > 0 {
+			i.SetInt64(n)
+			num.Mul(num, q)
+			num.Mul(num, i)
+			den.Mul(den, q)
+			den.Mul(den, i)
+			pow.Mul(pow, p)
+		}
+		num.Add(num, pow)
+	}
+	return new(big.Rat).SetFrac(num, den), nil
+}
+
 func getPW(X *big.Rat, Y *big.Rat) float64 {
 	YX := Y.Add(Y, X)
 	res, _ := X.Quo(X, YX).Float64()
@@ -134,13 +191,17 @@ func getFullServiceLevel(intensity float64, agents int64, targetTime int64, aht
 	return serviceLevel
 }
 
+// CheckMaxOccupancy returns the least number of whole agents keeping occupancy below maxOccupancy
+// (0 < maxOccupancy <= 1), agents when their occupancy is already below it
 func CheckMaxOccupancy(intensity float64, agents float64, maxOccupancy float64) float64 {
-	occupancy := intensity / agents
-	for occupancy >= maxOccupancy {
-		agents++
-		occupancy = intensity / agents
+	if !(intensity/agents >= maxOccupancy) {
+		return agents
 	}
-	return agents
+	needed := math.Floor(intensity/maxOccupancy) + 1
+	if math.IsInf(needed, 0) || math.IsNaN(needed) {
+		return agents
+	}
+	return needed
 }
 
 func ApplyShrinkage(agents float64, shrinkage float64) float64 {
@@ -150,47 +211,164 @@ func ApplyShrinkage(agents float64, shrinkage float64) float64 {
 	return agents / (1 - shrinkage)
 }
 
-func getAgentsWithServiceLevel(fteParams FteParams) (float64, float64) {
+func (c *Calculator) getAgentsWithServiceLevel(ctx context.Context, fteParams FteParams) (float64, float64, Constraint, error) {
+	if fteParams.Patience > 0 {
+		return getAgentsWithErlangA(ctx, fteParams)
+	}
 	intensity := getIntensity(fteParams.Volume, fteParams.Aht, fteParams.IntervalLength)
 	agents := math.Floor(intensity + 1)
 
-	for getFullServiceLevel(intensity, int64(agents), fteParams.TargetTime, fteParams.Aht) < fteParams.TargetServiceLevel {
+	constraint := ConstraintServiceLevel
+	evaluator, err := c.newErlangCEvaluator(ctx, fteParams.Engine, intensity, int64(agents))
+	if err != nil {
+		return intensity, agents, constraint, err
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return intensity, agents, constraint, err
+		}
+		erlangC := evaluator.erlangC()
+		serviceLevel := getServiceLevel(erlangC, intensity, int64(agents), fteParams.TargetTime, fteParams.Aht)
+		asa := erlangC * float64(fteParams.Aht) / (agents - intensity)
+		missed := getMissedTarget(fteParams, serviceLevel, asa)
+		if missed == "" {
+			break
+		}
+		constraint = missed
+		evaluator.next()
 		agents++
 	}
 
-	return intensity, agents
+	return intensity, agents, constraint, nil
+}
+
+// getMissedTarget returns the first target the service level and average speed of answer miss, empty when all are met
+func getMissedTarget(fteParams FteParams, serviceLevel float64, asa float64) Constraint {
+	if serviceLevel < fteParams.TargetServiceLevel {
+		return ConstraintServiceLevel
+	}
+	if fteParams.TargetAsa > 0 && asa > float64(fteParams.TargetAsa) {
+		return ConstraintAsa
+	}
+	return ""
 }
 
 func GetNumberOfAgents(fteParams FteParams) FteResult {
+	return defaultCalculator.GetNumberOfAgents(fteParams)
+}
+
+func (c *Calculator) GetNumberOfAgents(fteParams FteParams) FteResult {
+	result, _ := c.runStrategy(context.Background(), fteParams)
+	return result
+}
+
+// GetNumberOfAgentsContext calculates number of agents like GetNumberOfAgents, invalid params are reported
+// as *RowError and the search for agents stops with ctx.Err() when ctx is done
+func GetNumberOfAgentsContext(ctx context.Context, fteParams FteParams) (FteResult, error) {
+	return defaultCalculator.GetNumberOfAgentsContext(ctx, fteParams)
+}
+
+// GetNumberOfAgentsContext calculates with the strategy of the channel like CalculateFte
+func (c *Calculator) GetNumberOfAgentsContext(ctx context.Context, fteParams FteParams) (FteResult, error) {
+	result, err := c.runChecked(ctx, fteParams)
+	if err != nil && ctx.Err() == nil {
+		err = &RowError{ID: fteParams.ID, Index: fteParams.Index, Err: err}
+	}
+	return result, err
+}
+
+// GetNumberOfAgentsErlang calculates number of agents with Erlang C, or Erlang A with patience, whatever
+// the channel. It is the calculation strategies build on, params are not validated and the search for agents
+// stops with ctx.Err() when ctx is done.
+func GetNumberOfAgentsErlang(ctx context.Context, fteParams FteParams) (FteResult, error) {
+	return defaultCalculator.GetNumberOfAgentsErlang(ctx, fteParams)
+}
+
+func (c *Calculator) GetNumberOfAgentsErlang(ctx context.Context, fteParams FteParams) (FteResult, error) {
 	var intensity float64
 	var agents float64
+	constraint := ConstraintServiceLevel
+	fteParams.Aht = getConcurrentAht(fteParams.Aht, fteParams.Concurrency, fteParams.ConcurrencyAhtInflation)
 	if fteParams.Volume < 0 || fteParams.Aht <= 0 {
 		intensity = 0
 		agents = 1
 	} else {
-		intensity, agents = getAgentsWithServiceLevel(fteParams)
+		var err error
+		intensity, agents, constraint, err = c.getAgentsWithServiceLevel(ctx, fteParams)
+		if err != nil {
+			return getEmptyResult(fteParams), err
+		}
 	}
 
-	if fteParams.MaxOccupancy > 0 {
-		agents = CheckMaxOccupancy(intensity, agents, fteParams.MaxOccupancy)
+	result, servers := c.getFteResult(fteParams, intensity, agents, constraint)
+	if err := c.setServiceKpis(ctx, &result, fteParams, servers); err != nil {
+		return getEmptyResult(fteParams), err
 	}
+	return result, nil
+}
 
-	agents = ApplyShrinkage(agents, fteParams.Shrinkage)
-
-	agentsInt := int64(math.Ceil(agents))
-
+// getEmptyResult returns result of a row that wasn't calculated
+func getEmptyResult(fteParams FteParams) FteResult {
 	return FteResult{
 		ID:        fteParams.ID,
 		Index:     fteParams.Index,
 		Timestamp: fteParams.Timestamp,
-		Volume:    agentsInt,
 	}
 }
 
-func getNumberOfAgentsParallel(fteParams FteParams, fteChan chan FteResult, wg *sync.WaitGroup) {
-	agents := GetNumberOfAgents(fteParams)
-	fteChan <- agents
-	wg.Done()
+// getFteResult turns agents needed for the traffic intensity into the headcount to schedule,
+// it also returns the number of servers (agents or concurrent sessions) handling the traffic
+func (c *Calculator) getFteResult(fteParams FteParams, intensity float64, agents float64, constraint Constraint) (FteResult, float64) {
+	rawAgents := agents
+	if fteParams.MaxOccupancy > 0 {
+		agents = CheckMaxOccupancy(intensity, agents, fteParams.MaxOccupancy)
+		if agents > rawAgents {
+			constraint = ConstraintOccupancy
+		}
+	}
+	servers := agents
+
+	agents = ApplyConcurrency(agents, fteParams.Concurrency)
+
+	minStaffingApplied := false
+	if fteParams.MinStaffingBeforeShrinkage && agents < float64(fteParams.MinStaffing) {
+		agents = float64(fteParams.MinStaffing)
+		servers = agents * math.Max(1, float64(fteParams.Concurrency))
+		minStaffingApplied = true
+	}
+
+	agents = ApplyShrinkage(agents, fteParams.Shrinkage)
+
+	agentsInt := c.round(agents)
+
+	if !fteParams.MinStaffingBeforeShrinkage && agentsInt < fteParams.MinStaffing {
+		agentsInt = fteParams.MinStaffing
+		// KPIs are of the floored headcount, like GetKpis of the same staffing
+		shrinkage := math.Min(fteParams.Shrinkage, 0.99)
+		servers = math.Floor(float64(agentsInt)*(1-shrinkage)) * math.Max(1, float64(fteParams.Concurrency))
+		minStaffingApplied = true
+	}
+
+	if minStaffingApplied {
+		constraint = ConstraintMinStaffing
+	}
+
+	occupancy := 0.0
+	if servers > 0 {
+		occupancy = intensity / servers
+	}
+
+	return FteResult{
+		ID:                 fteParams.ID,
+		Index:              fteParams.Index,
+		Timestamp:          fteParams.Timestamp,
+		Volume:             agentsInt,
+		MinStaffingApplied: minStaffingApplied,
+		Intensity:          intensity,
+		RawAgents:          int64(math.Ceil(ApplyConcurrency(rawAgents, fteParams.Concurrency))),
+		Occupancy:          occupancy,
+		Constraint:         constraint,
+	}, servers
 }
 
 // CalculateFte calculats number of agents needed for a specific service level to handle incoming volume of arrivals per time interval
@@ -200,12 +378,23 @@ func getNumberOfAgentsParallel(fteParams FteParams, fteChan chan FteResult, wg *
 // aht - average handle time in seconds
 // targetServiceLevel - service level goal, the percentage of calls answered within the acceptable waiting time (0 <= targetServiceLevel < 1)
 // targetTime - target answer time, acceptable wait time in seconds
+// targetAsa - maximum average speed of answer in seconds, ignored when 0
 // maxOccupancy - maximum occupancy rate (0 <= maxOccupancy <= 1)
 // shrinkage - shrinkage rate (0 <= shrinkage < 1)
+// channel - calculation strategy, see RegisterStrategy
+// patience - average time callers wait before abandoning in seconds, enables Erlang A when > 0
+// minStaffing - minimum number of agents, applied to the final headcount or, with minStaffingBeforeShrinkage, to agents before shrinkage
+// concurrency - number of sessions (chats, messages) an agent handles at once
+// concurrencyAhtInflation - share of aht added to each session per extra concurrent session
+// engine - EngineExact, EngineFloat for faster float64 calculation, EngineApprox for approximation or EngineDefault for the engine of the calculator
 func CalculateFte(params []FteParams) []FteResult {
+	return defaultCalculator.CalculateFte(params)
+}
+
+func (c *Calculator) CalculateFte(params []FteParams) []FteResult {
 	fte := make([]FteResult, len(params))
 	for i, param := range params {
-		fte[i] = GetNumberOfAgents(param)
+		fte[i], _ = c.runStrategy(context.Background(), param)
 	}
 
 	return fte
@@ -218,23 +407,20 @@ func CalculateFte(params []FteParams) []FteResult {
 // aht - average handle time in seconds
 // targetServiceLevel - service level goal, the percentage of calls answered within the acceptable waiting time (0 <= targetServiceLevel < 1)
 // targetTime - target answer time, acceptable wait time in seconds
+// targetAsa - maximum average speed of answer in seconds, ignored when 0
 // maxOccupancy - maximum occupancy rate (0 <= maxOccupancy <= 1)
 // shrinkage - shrinkage rate (0 <= shrinkage < 1)
+// channel - calculation strategy, see RegisterStrategy
+// patience - average time callers wait before abandoning in seconds, enables Erlang A when > 0
+// minStaffing - minimum number of agents, applied to the final headcount or, with minStaffingBeforeShrinkage, to agents before shrinkage
+// concurrency - number of sessions (chats, messages) an agent handles at once
+// concurrencyAhtInflation - share of aht added to each session per extra concurrent session
+// engine - EngineExact, EngineFloat for faster float64 calculation, EngineApprox for approximation or EngineDefault for the engine of the calculator
 func CalculateFteParallel(params []FteParams) []FteResult {
-	var fte []FteResult
-	fteChan := make(chan FteResult, len(params))
-	wg := sync.WaitGroup{}
-	for _, param := range params {
-		wg.Add(1)
-		go getNumberOfAgentsParallel(param, fteChan, &wg)
-	}
-	wg.Wait()
-
-	close(fteChan)
-
-	for agents := range fteChan {
-		fte = append(fte, agents)
-	}
+	return defaultCalculator.CalculateFteParallel(params)
+}
 
+func (c *Calculator) CalculateFteParallel(params []FteParams) []FteResult {
+	fte, _ := c.calculateParallel(context.Background(), params, c.runStrategy)
 	return fte
 }
@@ -9,7 +9,7 @@ import (
 	"runtime/pprof"
 	"testing"
 
-	big "github.com/ncw/gmp"
+	big "github.com/Tymeshift/erlang-c-go/internal/bignum"
 )
 
 func TestIntensity(t *testing.T) {
@@ -44,7 +44,6 @@ func TestGetAN(t *testing.T) {
 	res = getAN(new(big.Rat).SetFloat64(2606.300000), big.NewInt(2700))
 	pow := ratioExp(new(big.Rat).SetFloat64(2606.300000), big.NewInt(2700))
 	expected = pow
-	t.Error(pow.String())
 	if res.Cmp(expected) != 0 {
 		t.Errorf("AN should be %s, got %s", expected, res)
 	}
@@ -122,6 +121,26 @@ func TestGetErlangC(t *testing.T) {
 	}
 }
 
+func TestCheckMaxOccupancy(t *testing.T) {
+	for _, intensity := range []float64{0, 8, 33.33, 100, 2606.3} {
+		for _, maxOccupancy := range []float64{0.1, 0.5, 0.8, 0.85, 1} {
+			expected := math.Floor(intensity + 1)
+			for intensity/expected >= maxOccupancy {
+				expected++
+			}
+			if res := CheckMaxOccupancy(intensity, math.Floor(intensity+1), maxOccupancy); res != expected {
+				t.Errorf("agents of %f erlangs at occupancy %f should be %f, got %f", intensity, maxOccupancy, expected, res)
+			}
+		}
+	}
+	if res := CheckMaxOccupancy(1e9, 1, 1e-12); res < 1e21 {
+		t.Errorf("agents of huge traffic at tiny occupancy should be over 1e21, got %f", res)
+	}
+	if res := CheckMaxOccupancy(8, 9, 0); res != 9 {
+		t.Errorf("agents without occupancy limit should stay 9, got %f", res)
+	}
+}
+
 func TestGetServiceLevel(t *testing.T) {
 	erlang := 0.4091801508
 	intensity := 8.0
@@ -2,6 +2,4 @@ module github.com/Tymeshift/erlang-c-go
 
 go 1.20
 
-require (
-	github.com/ncw/gmp v1.0.4
-)
+require github.com/ncw/gmp v1.0.4
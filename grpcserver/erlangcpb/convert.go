@@ -0,0 +1,89 @@
+package erlangcpb
+
+import erlangc "github.com/Tymeshift/erlang-c-go"
+
+// NewFteParams converts params of the calculation into a message
+func NewFteParams(p erlangc.FteParams) *FteParams {
+	return &FteParams{
+		Id:                         p.ID,
+		Index:                      p.Index,
+		Timestamp:                  p.Timestamp,
+		Volume:                     p.Volume,
+		IntervalLength:             p.IntervalLength,
+		Aht:                        p.Aht,
+		TargetServiceLevel:         p.TargetServiceLevel,
+		TargetTime:                 p.TargetTime,
+		TargetAsa:                  p.TargetAsa,
+		MaxOccupancy:               p.MaxOccupancy,
+		Shrinkage:                  p.Shrinkage,
+		Channel:                    p.Channel,
+		MinStaffing:                p.MinStaffing,
+		MinStaffingBeforeShrinkage: p.MinStaffingBeforeShrinkage,
+		Concurrency:                p.Concurrency,
+		ConcurrencyAhtInflation:    p.ConcurrencyAhtInflation,
+		Patience:                   p.Patience,
+		Engine:                     Engine(p.Engine),
+	}
+}
+
+// ToFteParams converts the message into params of the calculation
+func (x *FteParams) ToFteParams() erlangc.FteParams {
+	return erlangc.FteParams{
+		ID:                         x.GetId(),
+		Index:                      x.GetIndex(),
+		Timestamp:                  x.GetTimestamp(),
+		Volume:                     x.GetVolume(),
+		IntervalLength:             x.GetIntervalLength(),
+		Aht:                        x.GetAht(),
+		TargetServiceLevel:         x.GetTargetServiceLevel(),
+		TargetTime:                 x.GetTargetTime(),
+		TargetAsa:                  x.GetTargetAsa(),
+		MaxOccupancy:               x.GetMaxOccupancy(),
+		Shrinkage:                  x.GetShrinkage(),
+		Channel:                    x.GetChannel(),
+		MinStaffing:                x.GetMinStaffing(),
+		MinStaffingBeforeShrinkage: x.GetMinStaffingBeforeShrinkage(),
+		Concurrency:                x.GetConcurrency(),
+		ConcurrencyAhtInflation:    x.GetConcurrencyAhtInflation(),
+		Patience:                   x.GetPatience(),
+		Engine:                     erlangc.Engine(x.GetEngine()),
+	}
+}
+
+// NewFteResult converts result of the calculation into a message
+func NewFteResult(r erlangc.FteResult) *FteResult {
+	return &FteResult{
+		Id:                 r.ID,
+		Index:              r.Index,
+		Timestamp:          r.Timestamp,
+		Volume:             r.Volume,
+		MinStaffingApplied: r.MinStaffingApplied,
+		Intensity:          r.Intensity,
+		RawAgents:          r.RawAgents,
+		ServiceLevel:       r.ServiceLevel,
+		WaitProbability:    r.WaitProbability,
+		Asa:                r.Asa,
+		Abandonment:        r.Abandonment,
+		Occupancy:          r.Occupancy,
+		Constraint:         string(r.Constraint),
+	}
+}
+
+// ToFteResult converts the message into result of the calculation
+func (x *FteResult) ToFteResult() erlangc.FteResult {
+	return erlangc.FteResult{
+		ID:                 x.GetId(),
+		Index:              x.GetIndex(),
+		Timestamp:          x.GetTimestamp(),
+		Volume:             x.GetVolume(),
+		MinStaffingApplied: x.GetMinStaffingApplied(),
+		Intensity:          x.GetIntensity(),
+		RawAgents:          x.GetRawAgents(),
+		ServiceLevel:       x.GetServiceLevel(),
+		WaitProbability:    x.GetWaitProbability(),
+		Asa:                x.GetAsa(),
+		Abandonment:        x.GetAbandonment(),
+		Occupancy:          x.GetOccupancy(),
+		Constraint:         erlangc.Constraint(x.GetConstraint()),
+	}
+}
@@ -0,0 +1,713 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: erlangcpb/erlangc.proto
+
+package erlangcpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Engine int32
+
+const (
+	Engine_ENGINE_DEFAULT Engine = 0
+	Engine_ENGINE_EXACT   Engine = 1
+	Engine_ENGINE_FLOAT   Engine = 2
+	Engine_ENGINE_APPROX  Engine = 3
+)
+
+// Enum value maps for Engine.
+var (
+	Engine_name = map[int32]string{
+		0: "ENGINE_DEFAULT",
+		1: "ENGINE_EXACT",
+		2: "ENGINE_FLOAT",
+		3: "ENGINE_APPROX",
+	}
+	Engine_value = map[string]int32{
+		"ENGINE_DEFAULT": 0,
+		"ENGINE_EXACT":   1,
+		"ENGINE_FLOAT":   2,
+		"ENGINE_APPROX":  3,
+	}
+)
+
+func (x Engine) Enum() *Engine {
+	p := new(Engine)
+	*p = x
+	return p
+}
+
+func (x Engine) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Engine) Descriptor() protoreflect.EnumDescriptor {
+	return file_erlangcpb_erlangc_proto_enumTypes[0].Descriptor()
+}
+
+func (Engine) Type() protoreflect.EnumType {
+	return &file_erlangcpb_erlangc_proto_enumTypes[0]
+}
+
+func (x Engine) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Engine.Descriptor instead.
+func (Engine) EnumDescriptor() ([]byte, []int) {
+	return file_erlangcpb_erlangc_proto_rawDescGZIP(), []int{0}
+}
+
+type FteParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                         string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Index                      int64   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Timestamp                  int64   `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Volume                     float64 `protobuf:"fixed64,4,opt,name=volume,proto3" json:"volume,omitempty"`
+	IntervalLength             int64   `protobuf:"varint,5,opt,name=interval_length,json=intervalLength,proto3" json:"interval_length,omitempty"`
+	Aht                        int64   `protobuf:"varint,6,opt,name=aht,proto3" json:"aht,omitempty"`
+	TargetServiceLevel         float64 `protobuf:"fixed64,7,opt,name=target_service_level,json=targetServiceLevel,proto3" json:"target_service_level,omitempty"`
+	TargetTime                 int64   `protobuf:"varint,8,opt,name=target_time,json=targetTime,proto3" json:"target_time,omitempty"`
+	TargetAsa                  int64   `protobuf:"varint,9,opt,name=target_asa,json=targetAsa,proto3" json:"target_asa,omitempty"`
+	MaxOccupancy               float64 `protobuf:"fixed64,10,opt,name=max_occupancy,json=maxOccupancy,proto3" json:"max_occupancy,omitempty"`
+	Shrinkage                  float64 `protobuf:"fixed64,11,opt,name=shrinkage,proto3" json:"shrinkage,omitempty"`
+	Channel                    string  `protobuf:"bytes,12,opt,name=channel,proto3" json:"channel,omitempty"`
+	MinStaffing                int64   `protobuf:"varint,13,opt,name=min_staffing,json=minStaffing,proto3" json:"min_staffing,omitempty"`
+	MinStaffingBeforeShrinkage bool    `protobuf:"varint,14,opt,name=min_staffing_before_shrinkage,json=minStaffingBeforeShrinkage,proto3" json:"min_staffing_before_shrinkage,omitempty"`
+	Concurrency                int64   `protobuf:"varint,15,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	ConcurrencyAhtInflation    float64 `protobuf:"fixed64,16,opt,name=concurrency_aht_inflation,json=concurrencyAhtInflation,proto3" json:"concurrency_aht_inflation,omitempty"`
+	Patience                   int64   `protobuf:"varint,17,opt,name=patience,proto3" json:"patience,omitempty"`
+	Engine                     Engine  `protobuf:"varint,18,opt,name=engine,proto3,enum=erlangc.v1.Engine" json:"engine,omitempty"`
+}
+
+func (x *FteParams) Reset() {
+	*x = FteParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_erlangcpb_erlangc_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FteParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FteParams) ProtoMessage() {}
+
+func (x *FteParams) ProtoReflect() protoreflect.Message {
+	mi := &file_erlangcpb_erlangc_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FteParams.ProtoReflect.Descriptor instead.
+func (*FteParams) Descriptor() ([]byte, []int) {
+	return file_erlangcpb_erlangc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FteParams) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FteParams) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *FteParams) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *FteParams) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *FteParams) GetIntervalLength() int64 {
+	if x != nil {
+		return x.IntervalLength
+	}
+	return 0
+}
+
+func (x *FteParams) GetAht() int64 {
+	if x != nil {
+		return x.Aht
+	}
+	return 0
+}
+
+func (x *FteParams) GetTargetServiceLevel() float64 {
+	if x != nil {
+		return x.TargetServiceLevel
+	}
+	return 0
+}
+
+func (x *FteParams) GetTargetTime() int64 {
+	if x != nil {
+		return x.TargetTime
+	}
+	return 0
+}
+
+func (x *FteParams) GetTargetAsa() int64 {
+	if x != nil {
+		return x.TargetAsa
+	}
+	return 0
+}
+
+func (x *FteParams) GetMaxOccupancy() float64 {
+	if x != nil {
+		return x.MaxOccupancy
+	}
+	return 0
+}
+
+func (x *FteParams) GetShrinkage() float64 {
+	if x != nil {
+		return x.Shrinkage
+	}
+	return 0
+}
+
+func (x *FteParams) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *FteParams) GetMinStaffing() int64 {
+	if x != nil {
+		return x.MinStaffing
+	}
+	return 0
+}
+
+func (x *FteParams) GetMinStaffingBeforeShrinkage() bool {
+	if x != nil {
+		return x.MinStaffingBeforeShrinkage
+	}
+	return false
+}
+
+func (x *FteParams) GetConcurrency() int64 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *FteParams) GetConcurrencyAhtInflation() float64 {
+	if x != nil {
+		return x.ConcurrencyAhtInflation
+	}
+	return 0
+}
+
+func (x *FteParams) GetPatience() int64 {
+	if x != nil {
+		return x.Patience
+	}
+	return 0
+}
+
+func (x *FteParams) GetEngine() Engine {
+	if x != nil {
+		return x.Engine
+	}
+	return Engine_ENGINE_DEFAULT
+}
+
+type FteResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Index              int64   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Timestamp          int64   `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Volume             int64   `protobuf:"varint,4,opt,name=volume,proto3" json:"volume,omitempty"`
+	MinStaffingApplied bool    `protobuf:"varint,5,opt,name=min_staffing_applied,json=minStaffingApplied,proto3" json:"min_staffing_applied,omitempty"`
+	Intensity          float64 `protobuf:"fixed64,6,opt,name=intensity,proto3" json:"intensity,omitempty"`
+	RawAgents          int64   `protobuf:"varint,7,opt,name=raw_agents,json=rawAgents,proto3" json:"raw_agents,omitempty"`
+	ServiceLevel       float64 `protobuf:"fixed64,8,opt,name=service_level,json=serviceLevel,proto3" json:"service_level,omitempty"`
+	WaitProbability    float64 `protobuf:"fixed64,9,opt,name=wait_probability,json=waitProbability,proto3" json:"wait_probability,omitempty"`
+	Asa                float64 `protobuf:"fixed64,10,opt,name=asa,proto3" json:"asa,omitempty"`
+	Occupancy          float64 `protobuf:"fixed64,11,opt,name=occupancy,proto3" json:"occupancy,omitempty"`
+	Constraint         string  `protobuf:"bytes,12,opt,name=constraint,proto3" json:"constraint,omitempty"`
+	Abandonment        float64 `protobuf:"fixed64,13,opt,name=abandonment,proto3" json:"abandonment,omitempty"`
+}
+
+func (x *FteResult) Reset() {
+	*x = FteResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_erlangcpb_erlangc_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FteResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FteResult) ProtoMessage() {}
+
+func (x *FteResult) ProtoReflect() protoreflect.Message {
+	mi := &file_erlangcpb_erlangc_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FteResult.ProtoReflect.Descriptor instead.
+func (*FteResult) Descriptor() ([]byte, []int) {
+	return file_erlangcpb_erlangc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FteResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FteResult) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *FteResult) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *FteResult) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *FteResult) GetMinStaffingApplied() bool {
+	if x != nil {
+		return x.MinStaffingApplied
+	}
+	return false
+}
+
+func (x *FteResult) GetIntensity() float64 {
+	if x != nil {
+		return x.Intensity
+	}
+	return 0
+}
+
+func (x *FteResult) GetRawAgents() int64 {
+	if x != nil {
+		return x.RawAgents
+	}
+	return 0
+}
+
+func (x *FteResult) GetServiceLevel() float64 {
+	if x != nil {
+		return x.ServiceLevel
+	}
+	return 0
+}
+
+func (x *FteResult) GetWaitProbability() float64 {
+	if x != nil {
+		return x.WaitProbability
+	}
+	return 0
+}
+
+func (x *FteResult) GetAsa() float64 {
+	if x != nil {
+		return x.Asa
+	}
+	return 0
+}
+
+func (x *FteResult) GetOccupancy() float64 {
+	if x != nil {
+		return x.Occupancy
+	}
+	return 0
+}
+
+func (x *FteResult) GetConstraint() string {
+	if x != nil {
+		return x.Constraint
+	}
+	return ""
+}
+
+func (x *FteResult) GetAbandonment() float64 {
+	if x != nil {
+		return x.Abandonment
+	}
+	return 0
+}
+
+type CalculateFteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Params []*FteParams `protobuf:"bytes,1,rep,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *CalculateFteRequest) Reset() {
+	*x = CalculateFteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_erlangcpb_erlangc_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CalculateFteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateFteRequest) ProtoMessage() {}
+
+func (x *CalculateFteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_erlangcpb_erlangc_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateFteRequest.ProtoReflect.Descriptor instead.
+func (*CalculateFteRequest) Descriptor() ([]byte, []int) {
+	return file_erlangcpb_erlangc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CalculateFteRequest) GetParams() []*FteParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type CalculateFteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*FteResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *CalculateFteResponse) Reset() {
+	*x = CalculateFteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_erlangcpb_erlangc_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CalculateFteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateFteResponse) ProtoMessage() {}
+
+func (x *CalculateFteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_erlangcpb_erlangc_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateFteResponse.ProtoReflect.Descriptor instead.
+func (*CalculateFteResponse) Descriptor() ([]byte, []int) {
+	return file_erlangcpb_erlangc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CalculateFteResponse) GetResults() []*FteResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_erlangcpb_erlangc_proto protoreflect.FileDescriptor
+
+var file_erlangcpb_erlangc_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x65, 0x72, 0x6c, 0x61, 0x6e, 0x67, 0x63, 0x70, 0x62, 0x2f, 0x65, 0x72, 0x6c, 0x61,
+	0x6e, 0x67, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x65, 0x72, 0x6c, 0x61, 0x6e,
+	0x67, 0x63, 0x2e, 0x76, 0x31, 0x22, 0xfd, 0x04, 0x0a, 0x09, 0x46, 0x74, 0x65, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12,
+	0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6c, 0x65, 0x6e, 0x67,
+	0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x68, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x68, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x61, 0x73, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x41, 0x73, 0x61, 0x12, 0x23, 0x0a, 0x0d,
+	0x6d, 0x61, 0x78, 0x5f, 0x6f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x4f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63,
+	0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x68, 0x72, 0x69, 0x6e, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x73, 0x68, 0x72, 0x69, 0x6e, 0x6b, 0x61, 0x67, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6e,
+	0x5f, 0x73, 0x74, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x67, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x6d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x67, 0x12, 0x41, 0x0a, 0x1d,
+	0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x65, 0x66,
+	0x6f, 0x72, 0x65, 0x5f, 0x73, 0x68, 0x72, 0x69, 0x6e, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x1a, 0x6d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x67,
+	0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x53, 0x68, 0x72, 0x69, 0x6e, 0x6b, 0x61, 0x67, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x12, 0x3a, 0x0a, 0x19, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x5f, 0x61, 0x68, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x17, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x41, 0x68, 0x74, 0x49, 0x6e, 0x66, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x61, 0x74, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x70, 0x61, 0x74, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x65, 0x72, 0x6c, 0x61,
+	0x6e, 0x67, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x52, 0x06, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x22, 0x98, 0x03, 0x0a, 0x09, 0x46, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12,
+	0x30, 0x0a, 0x14, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x67, 0x5f,
+	0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x6d,
+	0x69, 0x6e, 0x53, 0x74, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x67, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x12,
+	0x1d, 0x0a, 0x0a, 0x72, 0x61, 0x77, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x61, 0x77, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x29, 0x0a, 0x10, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x62,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x77,
+	0x61, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x61, 0x73, 0x61, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x61, 0x73, 0x61,
+	0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x09, 0x6f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x20,
+	0x0a, 0x0b, 0x61, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0b, 0x61, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
+	0x22, 0x44, 0x0a, 0x13, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x46, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x72, 0x6c, 0x61, 0x6e, 0x67,
+	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x47, 0x0a, 0x14, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x65, 0x46, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f,
+	0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x65, 0x72, 0x6c, 0x61, 0x6e, 0x67, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x2a,
+	0x53, 0x0a, 0x06, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x4e, 0x47,
+	0x49, 0x4e, 0x45, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x10, 0x0a,
+	0x0c, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x45, 0x58, 0x41, 0x43, 0x54, 0x10, 0x01, 0x12,
+	0x10, 0x0a, 0x0c, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10,
+	0x02, 0x12, 0x11, 0x0a, 0x0d, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x41, 0x50, 0x50, 0x52,
+	0x4f, 0x58, 0x10, 0x03, 0x32, 0xe5, 0x01, 0x0a, 0x0e, 0x45, 0x72, 0x6c, 0x61, 0x6e, 0x67, 0x43,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x15, 0x2e, 0x65,
+	0x72, 0x6c, 0x61, 0x6e, 0x67, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x74, 0x65, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x1a, 0x15, 0x2e, 0x65, 0x72, 0x6c, 0x61, 0x6e, 0x67, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x51, 0x0a, 0x0c, 0x43, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x46, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x65, 0x72, 0x6c,
+	0x61, 0x6e, 0x67, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x65, 0x46, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x65, 0x72,
+	0x6c, 0x61, 0x6e, 0x67, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
+	0x74, 0x65, 0x46, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a,
+	0x09, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x74, 0x65, 0x12, 0x15, 0x2e, 0x65, 0x72, 0x6c,
+	0x61, 0x6e, 0x67, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x1a, 0x15, 0x2e, 0x65, 0x72, 0x6c, 0x61, 0x6e, 0x67, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x46,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x28, 0x01, 0x30, 0x01, 0x42, 0x37, 0x5a, 0x35,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x54, 0x79, 0x6d, 0x65, 0x73,
+	0x68, 0x69, 0x66, 0x74, 0x2f, 0x65, 0x72, 0x6c, 0x61, 0x6e, 0x67, 0x2d, 0x63, 0x2d, 0x67, 0x6f,
+	0x2f, 0x67, 0x72, 0x70, 0x63, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x65, 0x72, 0x6c, 0x61,
+	0x6e, 0x67, 0x63, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_erlangcpb_erlangc_proto_rawDescOnce sync.Once
+	file_erlangcpb_erlangc_proto_rawDescData = file_erlangcpb_erlangc_proto_rawDesc
+)
+
+func file_erlangcpb_erlangc_proto_rawDescGZIP() []byte {
+	file_erlangcpb_erlangc_proto_rawDescOnce.Do(func() {
+		file_erlangcpb_erlangc_proto_rawDescData = protoimpl.X.CompressGZIP(file_erlangcpb_erlangc_proto_rawDescData)
+	})
+	return file_erlangcpb_erlangc_proto_rawDescData
+}
+
+var file_erlangcpb_erlangc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_erlangcpb_erlangc_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_erlangcpb_erlangc_proto_goTypes = []interface{}{
+	(Engine)(0),                  // 0: erlangc.v1.Engine
+	(*FteParams)(nil),            // 1: erlangc.v1.FteParams
+	(*FteResult)(nil),            // 2: erlangc.v1.FteResult
+	(*CalculateFteRequest)(nil),  // 3: erlangc.v1.CalculateFteRequest
+	(*CalculateFteResponse)(nil), // 4: erlangc.v1.CalculateFteResponse
+}
+var file_erlangcpb_erlangc_proto_depIdxs = []int32{
+	0, // 0: erlangc.v1.FteParams.engine:type_name -> erlangc.v1.Engine
+	1, // 1: erlangc.v1.CalculateFteRequest.params:type_name -> erlangc.v1.FteParams
+	2, // 2: erlangc.v1.CalculateFteResponse.results:type_name -> erlangc.v1.FteResult
+	1, // 3: erlangc.v1.ErlangCService.GetNumberOfAgents:input_type -> erlangc.v1.FteParams
+	3, // 4: erlangc.v1.ErlangCService.CalculateFte:input_type -> erlangc.v1.CalculateFteRequest
+	1, // 5: erlangc.v1.ErlangCService.StreamFte:input_type -> erlangc.v1.FteParams
+	2, // 6: erlangc.v1.ErlangCService.GetNumberOfAgents:output_type -> erlangc.v1.FteResult
+	4, // 7: erlangc.v1.ErlangCService.CalculateFte:output_type -> erlangc.v1.CalculateFteResponse
+	2, // 8: erlangc.v1.ErlangCService.StreamFte:output_type -> erlangc.v1.FteResult
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_erlangcpb_erlangc_proto_init() }
+func file_erlangcpb_erlangc_proto_init() {
+	if File_erlangcpb_erlangc_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_erlangcpb_erlangc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FteParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_erlangcpb_erlangc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FteResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_erlangcpb_erlangc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CalculateFteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_erlangcpb_erlangc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CalculateFteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_erlangcpb_erlangc_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_erlangcpb_erlangc_proto_goTypes,
+		DependencyIndexes: file_erlangcpb_erlangc_proto_depIdxs,
+		EnumInfos:         file_erlangcpb_erlangc_proto_enumTypes,
+		MessageInfos:      file_erlangcpb_erlangc_proto_msgTypes,
+	}.Build()
+	File_erlangcpb_erlangc_proto = out.File
+	file_erlangcpb_erlangc_proto_rawDesc = nil
+	file_erlangcpb_erlangc_proto_goTypes = nil
+	file_erlangcpb_erlangc_proto_depIdxs = nil
+}
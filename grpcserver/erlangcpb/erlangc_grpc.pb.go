@@ -0,0 +1,216 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: erlangcpb/erlangc.proto
+
+package erlangcpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ErlangCService_GetNumberOfAgents_FullMethodName = "/erlangc.v1.ErlangCService/GetNumberOfAgents"
+	ErlangCService_CalculateFte_FullMethodName      = "/erlangc.v1.ErlangCService/CalculateFte"
+	ErlangCService_StreamFte_FullMethodName         = "/erlangc.v1.ErlangCService/StreamFte"
+)
+
+// ErlangCServiceClient is the client API for ErlangCService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ErlangCServiceClient interface {
+	GetNumberOfAgents(ctx context.Context, in *FteParams, opts ...grpc.CallOption) (*FteResult, error)
+	CalculateFte(ctx context.Context, in *CalculateFteRequest, opts ...grpc.CallOption) (*CalculateFteResponse, error)
+	StreamFte(ctx context.Context, opts ...grpc.CallOption) (ErlangCService_StreamFteClient, error)
+}
+
+type erlangCServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewErlangCServiceClient(cc grpc.ClientConnInterface) ErlangCServiceClient {
+	return &erlangCServiceClient{cc}
+}
+
+func (c *erlangCServiceClient) GetNumberOfAgents(ctx context.Context, in *FteParams, opts ...grpc.CallOption) (*FteResult, error) {
+	out := new(FteResult)
+	err := c.cc.Invoke(ctx, ErlangCService_GetNumberOfAgents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *erlangCServiceClient) CalculateFte(ctx context.Context, in *CalculateFteRequest, opts ...grpc.CallOption) (*CalculateFteResponse, error) {
+	out := new(CalculateFteResponse)
+	err := c.cc.Invoke(ctx, ErlangCService_CalculateFte_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *erlangCServiceClient) StreamFte(ctx context.Context, opts ...grpc.CallOption) (ErlangCService_StreamFteClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ErlangCService_ServiceDesc.Streams[0], ErlangCService_StreamFte_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &erlangCServiceStreamFteClient{stream}
+	return x, nil
+}
+
+type ErlangCService_StreamFteClient interface {
+	Send(*FteParams) error
+	Recv() (*FteResult, error)
+	grpc.ClientStream
+}
+
+type erlangCServiceStreamFteClient struct {
+	grpc.ClientStream
+}
+
+func (x *erlangCServiceStreamFteClient) Send(m *FteParams) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *erlangCServiceStreamFteClient) Recv() (*FteResult, error) {
+	m := new(FteResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ErlangCServiceServer is the server API for ErlangCService service.
+// All implementations must embed UnimplementedErlangCServiceServer
+// for forward compatibility
+type ErlangCServiceServer interface {
+	GetNumberOfAgents(context.Context, *FteParams) (*FteResult, error)
+	CalculateFte(context.Context, *CalculateFteRequest) (*CalculateFteResponse, error)
+	StreamFte(ErlangCService_StreamFteServer) error
+	mustEmbedUnimplementedErlangCServiceServer()
+}
+
+// UnimplementedErlangCServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedErlangCServiceServer struct {
+}
+
+func (UnimplementedErlangCServiceServer) GetNumberOfAgents(context.Context, *FteParams) (*FteResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNumberOfAgents not implemented")
+}
+func (UnimplementedErlangCServiceServer) CalculateFte(context.Context, *CalculateFteRequest) (*CalculateFteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CalculateFte not implemented")
+}
+func (UnimplementedErlangCServiceServer) StreamFte(ErlangCService_StreamFteServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFte not implemented")
+}
+func (UnimplementedErlangCServiceServer) mustEmbedUnimplementedErlangCServiceServer() {}
+
+// UnsafeErlangCServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ErlangCServiceServer will
+// result in compilation errors.
+type UnsafeErlangCServiceServer interface {
+	mustEmbedUnimplementedErlangCServiceServer()
+}
+
+func RegisterErlangCServiceServer(s grpc.ServiceRegistrar, srv ErlangCServiceServer) {
+	s.RegisterService(&ErlangCService_ServiceDesc, srv)
+}
+
+func _ErlangCService_GetNumberOfAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FteParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ErlangCServiceServer).GetNumberOfAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ErlangCService_GetNumberOfAgents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ErlangCServiceServer).GetNumberOfAgents(ctx, req.(*FteParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ErlangCService_CalculateFte_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateFteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ErlangCServiceServer).CalculateFte(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ErlangCService_CalculateFte_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ErlangCServiceServer).CalculateFte(ctx, req.(*CalculateFteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ErlangCService_StreamFte_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ErlangCServiceServer).StreamFte(&erlangCServiceStreamFteServer{stream})
+}
+
+type ErlangCService_StreamFteServer interface {
+	Send(*FteResult) error
+	Recv() (*FteParams, error)
+	grpc.ServerStream
+}
+
+type erlangCServiceStreamFteServer struct {
+	grpc.ServerStream
+}
+
+func (x *erlangCServiceStreamFteServer) Send(m *FteResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *erlangCServiceStreamFteServer) Recv() (*FteParams, error) {
+	m := new(FteParams)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ErlangCService_ServiceDesc is the grpc.ServiceDesc for ErlangCService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ErlangCService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "erlangc.v1.ErlangCService",
+	HandlerType: (*ErlangCServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetNumberOfAgents",
+			Handler:    _ErlangCService_GetNumberOfAgents_Handler,
+		},
+		{
+			MethodName: "CalculateFte",
+			Handler:    _ErlangCService_CalculateFte_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamFte",
+			Handler:       _ErlangCService_StreamFte_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "erlangcpb/erlangc.proto",
+}
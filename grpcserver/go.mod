@@ -0,0 +1,20 @@
+module github.com/Tymeshift/erlang-c-go/grpcserver
+
+go 1.20
+
+require (
+	github.com/Tymeshift/erlang-c-go v0.0.0-00010101000000-000000000000
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240123012728-ef4313101c80
+	google.golang.org/grpc v1.62.1
+	google.golang.org/protobuf v1.33.0
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/ncw/gmp v1.0.4 // indirect
+	golang.org/x/net v0.20.0 // indirect
+	golang.org/x/sys v0.16.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace github.com/Tymeshift/erlang-c-go => ../
@@ -0,0 +1,179 @@
+// Package grpcserver implements erlangcpb.ErlangCServiceServer with a calculator.
+//
+//	s := grpc.NewServer()
+//	erlangcpb.RegisterErlangCServiceServer(s, grpcserver.New(nil))
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+	"github.com/Tymeshift/erlang-c-go/grpcserver/erlangcpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMaxRows - maximum number of rows of a request
+const DefaultMaxRows = 100000
+
+// Server - gRPC service calculating number of agents
+type Server struct {
+	erlangcpb.UnimplementedErlangCServiceServer
+
+	calculator *erlangc.Calculator
+	maxRows    int
+}
+
+// Option - configuration of a Server
+type Option func(*Server)
+
+// WithMaxRows sets maximum number of rows of a request, DefaultMaxRows when not set
+func WithMaxRows(n int) Option {
+	return func(s *Server) {
+		s.maxRows = n
+	}
+}
+
+// New returns a service calculating with calculator, a calculator with default options when nil
+func New(calculator *erlangc.Calculator, options ...Option) *Server {
+	if calculator == nil {
+		calculator = erlangc.NewCalculator()
+	}
+	s := &Server{calculator: calculator, maxRows: DefaultMaxRows}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *Server) GetNumberOfAgents(ctx context.Context, req *erlangcpb.FteParams) (*erlangcpb.FteResult, error) {
+	params := []erlangc.FteParams{req.ToFteParams()}
+	if err := s.getValidationError(params); err != nil {
+		return nil, err
+	}
+	fte, err := s.calculator.CalculateFteContext(ctx, params)
+	if err != nil {
+		return nil, getStatusError(ctx, err)
+	}
+	return erlangcpb.NewFteResult(fte[0]), nil
+}
+
+func (s *Server) CalculateFte(ctx context.Context, req *erlangcpb.CalculateFteRequest) (*erlangcpb.CalculateFteResponse, error) {
+	params, err := s.getParams(req)
+	if err != nil {
+		return nil, err
+	}
+	fte, err := s.calculator.CalculateFteParallelContext(ctx, params)
+	if err != nil {
+		return nil, getStatusError(ctx, err)
+	}
+	res := &erlangcpb.CalculateFteResponse{Results: make([]*erlangcpb.FteResult, len(fte))}
+	for i, result := range fte {
+		res.Results[i] = erlangcpb.NewFteResult(result)
+	}
+	return res, nil
+}
+
+// StreamFte calculates params as the client sends them, the row limit doesn't apply to streams
+func (s *Server) StreamFte(stream erlangcpb.ErlangCService_StreamFteServer) error {
+	ctx := stream.Context()
+	next := func() (erlangc.FteParams, error) {
+		param, err := stream.Recv()
+		if err != nil {
+			return erlangc.FteParams{}, err
+		}
+		return param.ToFteParams(), nil
+	}
+	emit := func(result erlangc.FteResult) error {
+		return stream.Send(erlangcpb.NewFteResult(result))
+	}
+	if err := s.calculator.CalculateFteEach(ctx, next, emit); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return getStatusError(ctx, err)
+	}
+	return nil
+}
+
+func (s *Server) getParams(req *erlangcpb.CalculateFteRequest) ([]erlangc.FteParams, error) {
+	if len(req.GetParams()) > s.maxRows {
+		return nil, status.Errorf(codes.ResourceExhausted, "%d rows exceed the limit of %d", len(req.GetParams()), s.maxRows)
+	}
+	params := make([]erlangc.FteParams, len(req.GetParams()))
+	for i, param := range req.GetParams() {
+		params[i] = param.ToFteParams()
+	}
+	if err := s.getValidationError(params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// getValidationError returns INVALID_ARGUMENT with a violation of every invalid field, nil when all rows are valid
+func (s *Server) getValidationError(params []erlangc.FteParams) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	invalid := 0
+	for i, param := range params {
+		err := s.calculator.Validate(param)
+		if err == nil {
+			continue
+		}
+		invalid++
+		violations = append(violations, getViolations(i, err)...)
+	}
+	if invalid == 0 {
+		return nil
+	}
+	return getBadRequest(fmt.Sprintf("%d of %d rows are invalid", invalid, len(params)), violations)
+}
+
+func getViolations(row int, err error) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, err := range unwrapJoined(err) {
+		field := fmt.Sprintf("params[%d]", row)
+		var fieldErr *erlangc.FieldError
+		if errors.As(err, &fieldErr) {
+			field += "." + fieldErr.Field
+		}
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: err.Error()})
+	}
+	return violations
+}
+
+// getStatusError converts errors of a batch into a status, rows failed by their strategy are INVALID_ARGUMENT
+func getStatusError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, err := range unwrapJoined(err) {
+		var rowErr *erlangc.RowError
+		if errors.As(err, &rowErr) {
+			violations = append(violations, getViolations(rowErr.Row, rowErr.Err)...)
+		}
+	}
+	if len(violations) == 0 {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return getBadRequest(err.Error(), violations)
+}
+
+func getBadRequest(message string, violations []*errdetails.BadRequest_FieldViolation) error {
+	st, err := status.New(codes.InvalidArgument, message).WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, message)
+	}
+	return st.Err()
+}
+
+// unwrapJoined returns errors joined with errors.Join, or err itself
+func unwrapJoined(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
@@ -0,0 +1,144 @@
+package grpcserver
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+	"github.com/Tymeshift/erlang-c-go/grpcserver/erlangcpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var params = []erlangc.FteParams{
+	{ID: "1", Index: 0, Volume: 500, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 20, Shrinkage: 0.3},
+	{ID: "1", Index: 1, Volume: 250, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 20, Shrinkage: 0.3},
+	{ID: "1", Index: 2, Volume: 50, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 20, Patience: 60},
+}
+
+func newClient(t *testing.T, s *Server) erlangcpb.ErlangCServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	erlangcpb.RegisterErlangCServiceServer(server, s)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	dial := func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(dial), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return erlangcpb.NewErlangCServiceClient(conn)
+}
+
+func getRequest(params []erlangc.FteParams) *erlangcpb.CalculateFteRequest {
+	req := &erlangcpb.CalculateFteRequest{}
+	for _, param := range params {
+		req.Params = append(req.Params, erlangcpb.NewFteParams(param))
+	}
+	return req
+}
+
+// streamFte sends params on a new stream and closes it, results are read from the returned stream
+func streamFte(t *testing.T, client erlangcpb.ErlangCServiceClient, params []erlangc.FteParams) erlangcpb.ErlangCService_StreamFteClient {
+	stream, err := client.StreamFte(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for _, param := range params {
+			if err := stream.Send(erlangcpb.NewFteParams(param)); err != nil {
+				return
+			}
+		}
+		stream.CloseSend()
+	}()
+	return stream
+}
+
+func TestCalculateFte(t *testing.T) {
+	client := newClient(t, New(nil))
+	ctx := context.Background()
+	expected := erlangc.CalculateFte(params)
+
+	res, err := client.GetNumberOfAgents(ctx, erlangcpb.NewFteParams(params[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ToFteResult() != expected[0] {
+		t.Errorf("result should be %+v, got %+v", expected[0], res.ToFteResult())
+	}
+
+	batch, err := client.CalculateFte(ctx, getRequest(params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, result := range batch.GetResults() {
+		if result.ToFteResult() != expected[i] {
+			t.Errorf("row %d should be %+v, got %+v", i, expected[i], result.ToFteResult())
+		}
+	}
+
+	stream := streamFte(t, client, params)
+	for i := 0; ; i++ {
+		result, err := stream.Recv()
+		if err == io.EOF {
+			if i != len(expected) {
+				t.Errorf("stream should have %d results, got %d", len(expected), i)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.ToFteResult() != expected[i] {
+			t.Errorf("streamed row %d should be %+v, got %+v", i, expected[i], result.ToFteResult())
+		}
+	}
+}
+
+func TestCalculateFteInvalid(t *testing.T) {
+	client := newClient(t, New(nil, WithMaxRows(3)))
+	ctx := context.Background()
+	invalid := append([]erlangc.FteParams{}, params...)
+	invalid[1].TargetServiceLevel = 80
+
+	_, err := client.CalculateFte(ctx, getRequest(invalid))
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code should be InvalidArgument, got %v", st.Code())
+	}
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations = badRequest.GetFieldViolations()
+		}
+	}
+	if len(violations) != 1 || violations[0].GetField() != "params[1].TargetServiceLevel" {
+		t.Errorf("violation should be of params[1].TargetServiceLevel, got %v", violations)
+	}
+
+	stream := streamFte(t, client, invalid)
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("row before the invalid one should be streamed, got %v", err)
+	}
+	_, err = stream.Recv()
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("stream code should be InvalidArgument, got %v", err)
+	}
+
+	_, err = client.CalculateFte(ctx, getRequest(append(params, params[0])))
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("code over the row limit should be ResourceExhausted, got %v", err)
+	}
+}
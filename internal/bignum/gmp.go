@@ -0,0 +1,27 @@
+//go:build cgo && !purego
+
+// Package bignum selects the arbitrary precision backend of the exact engine,
+// GMP through cgo by default or math/big with the purego build tag or without cgo.
+package bignum
+
+import (
+	stdbig "math/big"
+
+	"github.com/ncw/gmp"
+)
+
+type Int = gmp.Int
+type Rat = gmp.Rat
+
+func NewInt(x int64) *Int {
+	return gmp.NewInt(x)
+}
+
+func NewRat(a, b int64) *Rat {
+	return gmp.NewRat(a, b)
+}
+
+// FromBig converts a math/big integer, it must be non-negative
+func FromBig(x *stdbig.Int) *Int {
+	return new(gmp.Int).SetBytes(x.Bytes())
+}
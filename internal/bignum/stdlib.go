@@ -0,0 +1,23 @@
+//go:build !cgo || purego
+
+// Package bignum selects the arbitrary precision backend of the exact engine,
+// GMP through cgo by default or math/big with the purego build tag or without cgo.
+package bignum
+
+import "math/big"
+
+type Int = big.Int
+type Rat = big.Rat
+
+func NewInt(x int64) *Int {
+	return big.NewInt(x)
+}
+
+func NewRat(a, b int64) *Rat {
+	return big.NewRat(a, b)
+}
+
+// FromBig converts a math/big integer, it must be non-negative
+func FromBig(x *big.Int) *Int {
+	return x
+}
@@ -0,0 +1,76 @@
+package erlangc
+
+import (
+	"context"
+	"math"
+)
+
+// KpiParams - parameters to calculate KPIs of a scheduled number of agents
+type KpiParams struct {
+	FteParams
+	Agents int64
+}
+
+// GetKpis calculates service level, probability of wait, average speed of answer and occupancy
+// the scheduled agents deliver, shrinkage is taken out of the scheduled agents before calculation.
+// Occupancy of 1 or more means the agents can't keep up with the traffic.
+func GetKpis(kpiParams KpiParams) FteResult {
+	return defaultCalculator.GetKpis(kpiParams)
+}
+
+func (c *Calculator) GetKpis(kpiParams KpiParams) FteResult {
+	fteParams := kpiParams.FteParams
+	fteParams.Aht = getConcurrentAht(fteParams.Aht, fteParams.Concurrency, fteParams.ConcurrencyAhtInflation)
+
+	intensity := 0.0
+	if fteParams.Volume > 0 && fteParams.Aht > 0 {
+		intensity = getIntensity(fteParams.Volume, fteParams.Aht, fteParams.IntervalLength)
+	}
+
+	shrinkage := fteParams.Shrinkage
+	if shrinkage >= 1 {
+		shrinkage = 0.99
+	}
+	agents := math.Floor(float64(kpiParams.Agents) * (1 - shrinkage))
+	servers := agents * math.Max(1, float64(fteParams.Concurrency))
+
+	occupancy := 0.0
+	if servers > 0 {
+		occupancy = intensity / servers
+	}
+
+	result := FteResult{
+		ID:        fteParams.ID,
+		Index:     fteParams.Index,
+		Timestamp: fteParams.Timestamp,
+		Volume:    kpiParams.Agents,
+		Intensity: intensity,
+		RawAgents: int64(agents),
+		Occupancy: occupancy,
+	}
+	c.setServiceKpis(context.Background(), &result, fteParams, servers)
+	return result
+}
+
+// CalculateKpis calculates KPIs the scheduled agents deliver for incoming volume of arrivals per time interval
+//
+// agents - number of scheduled agents
+// volume - incoming number of arrivals per time interval
+// intervalLength - time interval in seconds
+// aht - average handle time in seconds
+// targetTime - target answer time, acceptable wait time in seconds
+// shrinkage - shrinkage rate (0 <= shrinkage < 1)
+// patience - average time callers wait before abandoning in seconds, enables Erlang A when > 0
+// concurrency - number of sessions (chats, messages) an agent handles at once
+func CalculateKpis(params []KpiParams) []FteResult {
+	return defaultCalculator.CalculateKpis(params)
+}
+
+func (c *Calculator) CalculateKpis(params []KpiParams) []FteResult {
+	kpis := make([]FteResult, len(params))
+	for i, param := range params {
+		kpis[i] = c.GetKpis(param)
+	}
+
+	return kpis
+}
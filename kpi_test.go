@@ -0,0 +1,52 @@
+package erlangc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetKpis(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Index:              0,
+		Volume:             100,
+		IntervalLength:     900,
+		MaxOccupancy:       0.8,
+		Shrinkage:          0.2,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+	}
+	fte := GetNumberOfAgents(params)
+	kpis := GetKpis(KpiParams{FteParams: params, Agents: fte.Volume})
+	if kpis.RawAgents != 42 {
+		t.Errorf("productive agents of %d scheduled should be 42, got %d", fte.Volume, kpis.RawAgents)
+	}
+	if kpis.ServiceLevel != fte.ServiceLevel || kpis.Asa != fte.Asa || kpis.Occupancy != fte.Occupancy {
+		t.Errorf("KPIs of %d agents should match %+v, got %+v", fte.Volume, fte, kpis)
+	}
+
+	kpis = GetKpis(KpiParams{FteParams: params, Agents: 40})
+	if kpis.ServiceLevel != 0 || kpis.WaitProbability != 1 || kpis.Occupancy < 1 {
+		t.Errorf("32 productive agents can't handle 33.33 erlangs, got %+v", kpis)
+	}
+}
+
+func TestCalculateKpis(t *testing.T) {
+	var params []KpiParams
+	data := `[
+		{"ID": "1", "Index": 0, "Volume": 10, "IntervalLength": 900, "Aht": 300, "TargetTime": 60, "Agents": 5},
+		{"ID": "1", "Index": 1, "Volume": 10, "IntervalLength": 900, "Aht": 300, "TargetTime": 60, "Agents": 6}
+	]`
+	if err := json.Unmarshal([]byte(data), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	kpis := CalculateKpis(params)
+	if kpis[0].Volume != 5 || kpis[1].Index != 1 {
+		t.Errorf("results should follow params, got %+v", kpis)
+	}
+	if kpis[1].ServiceLevel <= kpis[0].ServiceLevel {
+		t.Errorf("service level should grow with agents, got %f and %f", kpis[0].ServiceLevel, kpis[1].ServiceLevel)
+	}
+}
@@ -0,0 +1,85 @@
+package erlangc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetNumberOfAgentsMinStaffing(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Index:              0,
+		Volume:             0.5,
+		IntervalLength:     900,
+		MaxOccupancy:       0.8,
+		Shrinkage:          0.2,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+		MinStaffing:        3,
+	}
+	num := GetNumberOfAgents(params)
+	if num.Volume != 3 || !num.MinStaffingApplied {
+		t.Errorf("min staffing should raise agents to 3, got %d (applied %t)", num.Volume, num.MinStaffingApplied)
+	}
+
+	params.MinStaffingBeforeShrinkage = true
+	num = GetNumberOfAgents(params)
+	if num.Volume != 4 || !num.MinStaffingApplied {
+		t.Errorf("min staffing before shrinkage should raise agents to 4, got %d (applied %t)", num.Volume, num.MinStaffingApplied)
+	}
+
+	params.Volume = 100
+	num = GetNumberOfAgents(params)
+	if num.Volume != 53 || num.MinStaffingApplied {
+		t.Errorf("demand should drive agents to 53, got %d (applied %t)", num.Volume, num.MinStaffingApplied)
+	}
+}
+
+func TestGetNumberOfAgentsMinStaffingKpis(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Volume:             20,
+		IntervalLength:     900,
+		Shrinkage:          0.2,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         20,
+	}
+	demand := GetNumberOfAgents(params)
+
+	params.MinStaffing = 20
+	num := GetNumberOfAgents(params)
+	if num.Volume != 20 || !num.MinStaffingApplied {
+		t.Fatalf("min staffing should raise agents to 20, got %d (applied %t)", num.Volume, num.MinStaffingApplied)
+	}
+	// KPIs are of the 20 scheduled agents, not of the demand
+	if num.ServiceLevel <= demand.ServiceLevel || num.Occupancy >= demand.Occupancy {
+		t.Errorf("floor should raise service level above %f and lower occupancy below %f, got %+v", demand.ServiceLevel, demand.Occupancy, num)
+	}
+	kpis := GetKpis(KpiParams{FteParams: params, Agents: 20})
+	if num.ServiceLevel != kpis.ServiceLevel || num.Occupancy != kpis.Occupancy || num.Asa != kpis.Asa {
+		t.Errorf("KPIs should match GetKpis %+v, got %+v", kpis, num)
+	}
+}
+
+func TestGetNumberOfAgentsLargeMinStaffing(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Volume:             20,
+		IntervalLength:     900,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         20,
+		MinStaffing:        DefaultMaxAgents,
+		Engine:             EngineExact,
+	}
+	start := time.Now()
+	num := GetNumberOfAgents(params)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("KPIs of a headcount far above the traffic should be quick, took %s", elapsed)
+	}
+	if num.Volume != DefaultMaxAgents || num.ServiceLevel < 1-1e-9 || num.WaitProbability > 1e-9 {
+		t.Errorf("%d agents should answer everybody at once, got %+v", DefaultMaxAgents, num)
+	}
+}
@@ -0,0 +1,46 @@
+package erlangc
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCalculateFteParallel(t *testing.T) {
+	params := readFteParams(t)
+	for i := range params {
+		params[i].Engine = EngineFloat
+	}
+	expected := CalculateFte(params)
+
+	for _, limit := range []int{0, 1, 7} {
+		fte, err := NewCalculator(WithConcurrency(limit)).CalculateFteParallelContext(context.Background(), params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range expected {
+			if fte[i] != expected[i] {
+				t.Fatalf("row %d with limit %d should be %+v, got %+v", i, limit, expected[i], fte[i])
+			}
+		}
+	}
+
+	fte := CalculateFteParallel(params)
+	if len(fte) != len(expected) || fte[len(fte)-1] != expected[len(expected)-1] {
+		t.Errorf("CalculateFteParallel should keep order of params")
+	}
+}
+
+func TestCalculateFteParallelCancel(t *testing.T) {
+	params := readFteParams(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fte, err := CalculateFteParallelContext(ctx, params)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error should be %s, got %v", context.Canceled, err)
+	}
+	if len(fte) != len(params) || fte[len(fte)-1].Volume != 0 {
+		t.Errorf("rows after cancellation should be skipped")
+	}
+}
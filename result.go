@@ -0,0 +1,45 @@
+package erlangc
+
+import "context"
+
+// Constraint - rule that determined the number of agents
+type Constraint string
+
+const (
+	ConstraintServiceLevel Constraint = "service_level"
+	ConstraintAsa          Constraint = "asa"
+	ConstraintOccupancy    Constraint = "occupancy"
+	ConstraintMinStaffing  Constraint = "min_staffing"
+	ConstraintWorkload     Constraint = "workload"
+)
+
+// setServiceKpis sets service level, probability of wait and average speed of answer the servers deliver,
+// it stops with ctx.Err() when ctx is done
+func (c *Calculator) setServiceKpis(ctx context.Context, result *FteResult, fteParams FteParams, servers float64) error {
+	if result.Intensity <= 0 {
+		result.ServiceLevel = 1
+		return nil
+	}
+	agents := int64(servers)
+	if fteParams.Patience > 0 {
+		erlangA := GetErlangA(result.Intensity, agents, fteParams.TargetTime, fteParams.Aht, fteParams.Patience)
+		result.ServiceLevel = erlangA.ServiceLevel
+		result.WaitProbability = erlangA.WaitProbability
+		result.Asa = erlangA.Asa
+		result.Abandonment = erlangA.Abandonment
+		// abandoned calls take no handle time
+		result.Occupancy *= 1 - erlangA.Abandonment
+		return nil
+	}
+	if servers <= result.Intensity {
+		// queue grows without bounds, nobody is answered in time
+		result.WaitProbability = 1
+		return nil
+	}
+	waitProbability, serviceLevel, asa, err := c.getEngineKpis(ctx, fteParams.Engine, result.Intensity, agents, fteParams.TargetTime, fteParams.Aht)
+	if err != nil {
+		return err
+	}
+	result.WaitProbability, result.ServiceLevel, result.Asa = waitProbability, serviceLevel, asa
+	return nil
+}
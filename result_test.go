@@ -0,0 +1,92 @@
+package erlangc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGetNumberOfAgentsKpis(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Index:              0,
+		Volume:             100,
+		IntervalLength:     900,
+		MaxOccupancy:       0.8,
+		Shrinkage:          0.2,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+	}
+	num := GetNumberOfAgents(params)
+	if num.Intensity != 33.33 || num.RawAgents != 38 {
+		t.Errorf("intensity and raw agents should be 33.33 and 38, got %f and %d", num.Intensity, num.RawAgents)
+	}
+	if num.Constraint != ConstraintOccupancy {
+		t.Errorf("constraint should be %s, got %s", ConstraintOccupancy, num.Constraint)
+	}
+	if math.Round(num.Occupancy*10000)/10000 != 0.7936 {
+		t.Errorf("occupancy of 42 agents should be 0.7936, got %f", num.Occupancy)
+	}
+	if math.Round(num.ServiceLevel*10000)/10000 != 0.9819 || math.Round(num.WaitProbability*10000)/10000 != 0.1027 {
+		t.Errorf("service level and probability of wait should be 0.9819 and 0.1027, got %f and %f", num.ServiceLevel, num.WaitProbability)
+	}
+	expectedAsa := num.WaitProbability * 300 / (42 - 33.33)
+	if math.Abs(num.Asa-expectedAsa) > 1e-9 {
+		t.Errorf("ASA should be %f, got %f", expectedAsa, num.Asa)
+	}
+
+	params.MaxOccupancy = 0.9
+	num = GetNumberOfAgents(params)
+	if num.Constraint != ConstraintServiceLevel || num.ServiceLevel < params.TargetServiceLevel {
+		t.Errorf("service level %f should be binding, got %s", num.ServiceLevel, num.Constraint)
+	}
+
+	params.Volume = 0.5
+	params.MinStaffing = 3
+	num = GetNumberOfAgents(params)
+	if num.Constraint != ConstraintMinStaffing {
+		t.Errorf("constraint should be %s, got %s", ConstraintMinStaffing, num.Constraint)
+	}
+}
+
+func TestGetNumberOfAgentsAbandonment(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Volume:             100,
+		IntervalLength:     900,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+		Patience:           60,
+	}
+	num := GetNumberOfAgents(params)
+	if num.Abandonment <= 0 || num.Abandonment >= 1 {
+		t.Fatalf("abandonment should be in (0, 1), got %f", num.Abandonment)
+	}
+	// abandoned calls don't keep agents busy
+	expected := num.Intensity * (1 - num.Abandonment) / float64(num.Volume)
+	if math.Abs(num.Occupancy-expected) > 1e-12 || num.Occupancy >= 1 {
+		t.Errorf("occupancy should be %f, got %f", expected, num.Occupancy)
+	}
+	kpis := GetKpis(KpiParams{FteParams: params, Agents: num.Volume})
+	if kpis.Abandonment != num.Abandonment || kpis.Occupancy != num.Occupancy {
+		t.Errorf("KPIs should match GetKpis %+v, got %+v", kpis, num)
+	}
+}
+
+func TestGetNumberOfAgentsConcurrencyRawAgents(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Volume:             100,
+		IntervalLength:     900,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+		Channel:            "chat",
+		Concurrency:        3,
+	}
+	num := GetNumberOfAgents(params)
+	if num.RawAgents > num.Volume || num.RawAgents*3 < int64(num.Intensity) {
+		t.Errorf("raw agents should be agents of %f erlangs in sessions of 3, up to %d, got %d", num.Intensity, num.Volume, num.RawAgents)
+	}
+}
@@ -0,0 +1,160 @@
+package erlangc
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// Period - calendar period of a roll-up
+type Period int
+
+const (
+	// PeriodDay groups intervals by calendar day
+	PeriodDay Period = iota
+	// PeriodWeek groups intervals by calendar week starting on RollupParams.WeekStart
+	PeriodWeek
+)
+
+// RollupParams - parameters to roll interval results up into calendar periods
+//
+// Period - calendar period of the roll-up
+// Location - time zone of calendar days, UTC when nil
+// WeekStart - first day of weeks, Sunday when not set
+// IntervalLength - length of every interval in seconds
+// TimestampUnit - unit of FteResult.Timestamp since the Unix epoch, time.Second when not set
+// ContractedHours - paid hours of a full-time agent per week
+// WorkingDays - working days of a full-time agent per week, 5 when not set
+type RollupParams struct {
+	Period          Period
+	Location        *time.Location
+	WeekStart       time.Weekday
+	IntervalLength  int64
+	TimestampUnit   time.Duration
+	ContractedHours float64
+	WorkingDays     float64
+}
+
+// RollupResult - staffing of an ID over a calendar period
+//
+// Start - start of the period as Unix seconds
+// Intervals - number of intervals in the period
+// PeakAgents - highest number of agents of an interval
+// AverageAgents - average number of agents of the intervals
+// StaffedHours - total paid hours of the agents
+// Fte - number of full-time agents working the staffed hours
+type RollupResult struct {
+	ID            string
+	Period        Period
+	Start         int64
+	Intervals     int64
+	PeakAgents    int64
+	AverageAgents float64
+	StaffedHours  float64
+	Fte           float64
+}
+
+// Validate checks that roll-up parameters are in their valid ranges, it returns all invalid fields as *FieldError
+func (rollupParams RollupParams) Validate() error {
+	var errs []error
+	check := func(valid bool, field string, value interface{}, reason string) {
+		if !valid {
+			errs = append(errs, &FieldError{Field: field, Value: value, Reason: reason})
+		}
+	}
+
+	check(rollupParams.Period == PeriodDay || rollupParams.Period == PeriodWeek, "Period", rollupParams.Period, "unknown period")
+	check(rollupParams.WeekStart >= time.Sunday && rollupParams.WeekStart <= time.Saturday, "WeekStart", rollupParams.WeekStart, "unknown weekday")
+	check(rollupParams.IntervalLength > 0, "IntervalLength", rollupParams.IntervalLength, "must be positive")
+	check(rollupParams.TimestampUnit >= 0, "TimestampUnit", rollupParams.TimestampUnit, "must not be negative")
+	check(rollupParams.ContractedHours > 0 && !math.IsInf(rollupParams.ContractedHours, 0), "ContractedHours", rollupParams.ContractedHours, "must be positive")
+	check(rollupParams.WorkingDays >= 0 && rollupParams.WorkingDays <= 7, "WorkingDays", rollupParams.WorkingDays, "must be in [0, 7]")
+
+	return errors.Join(errs...)
+}
+
+// getPeriodStart returns start of the calendar period of the timestamp in the location of the params
+func (rollupParams RollupParams) getPeriodStart(timestamp int64) time.Time {
+	location := rollupParams.Location
+	if location == nil {
+		location = time.UTC
+	}
+	unit := rollupParams.TimestampUnit
+	if unit == 0 {
+		unit = time.Second
+	}
+	var t time.Time
+	if unit >= time.Second {
+		t = time.Unix(timestamp*int64(unit/time.Second), 0)
+	} else {
+		perSecond := int64(time.Second / unit)
+		t = time.Unix(timestamp/perSecond, timestamp%perSecond*int64(unit))
+	}
+	t = t.In(location)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+	if rollupParams.Period == PeriodWeek {
+		days := (int(day.Weekday()) - int(rollupParams.WeekStart) + 7) % 7
+		day = day.AddDate(0, 0, -days)
+	}
+	return day
+}
+
+// getContractedHours returns paid hours of a full-time agent in the period
+func (rollupParams RollupParams) getContractedHours() float64 {
+	if rollupParams.Period == PeriodWeek {
+		return rollupParams.ContractedHours
+	}
+	workingDays := rollupParams.WorkingDays
+	if workingDays == 0 {
+		workingDays = 5
+	}
+	return rollupParams.ContractedHours / workingDays
+}
+
+// GetRollups groups interval results by ID and calendar period of their Timestamp and sums them up,
+// results are ordered by ID and start of the period. Periods are calendar days or weeks of the location,
+// so days are 23 or 25 hours long on daylight saving time changes.
+// Volume of an interval result is its number of agents, paid for the interval length.
+func GetRollups(fte []FteResult, rollupParams RollupParams) ([]RollupResult, error) {
+	if err := rollupParams.Validate(); err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		id    string
+		start int64
+	}
+	rollups := make(map[key]*RollupResult)
+	totalAgents := make(map[key]int64)
+	for _, result := range fte {
+		k := key{id: result.ID, start: rollupParams.getPeriodStart(result.Timestamp).Unix()}
+		rollup, ok := rollups[k]
+		if !ok {
+			rollup = &RollupResult{ID: result.ID, Period: rollupParams.Period, Start: k.start}
+			rollups[k] = rollup
+		}
+		rollup.Intervals++
+		if result.Volume > rollup.PeakAgents {
+			rollup.PeakAgents = result.Volume
+		}
+		totalAgents[k] += result.Volume
+	}
+
+	contractedHours := rollupParams.getContractedHours()
+	intervalHours := float64(rollupParams.IntervalLength) / 3600
+	results := make([]RollupResult, 0, len(rollups))
+	for k, rollup := range rollups {
+		rollup.AverageAgents = float64(totalAgents[k]) / float64(rollup.Intervals)
+		rollup.StaffedHours = float64(totalAgents[k]) * intervalHours
+		rollup.Fte = rollup.StaffedHours / contractedHours
+		results = append(results, *rollup)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].ID != results[j].ID {
+			return results[i].ID < results[j].ID
+		}
+		return results[i].Start < results[j].Start
+	})
+	return results, nil
+}
@@ -0,0 +1,63 @@
+package erlangc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetRollups(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	// 23:00 and 23:30 local time belong to the 1st of March, 00:00 and 00:30 to the 2nd
+	start := time.Date(2024, 3, 1, 23, 0, 0, 0, location).Unix()
+	var fte []FteResult
+	for i, agents := range []int64{10, 20, 30, 40} {
+		fte = append(fte, FteResult{ID: "b", Index: int64(i), Timestamp: start + int64(i)*1800, Volume: agents})
+		fte = append(fte, FteResult{ID: "a", Index: int64(i), Timestamp: start + int64(i)*1800, Volume: 1})
+	}
+
+	params := RollupParams{Period: PeriodDay, Location: location, IntervalLength: 1800, ContractedHours: 40}
+	rollups, err := GetRollups(fte, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rollups) != 4 || rollups[0].ID != "a" || rollups[2].ID != "b" {
+		t.Fatalf("should have 2 days of a and b, got %+v", rollups)
+	}
+	day := rollups[3]
+	if day.Start != time.Date(2024, 3, 2, 0, 0, 0, 0, location).Unix() {
+		t.Errorf("day should start at local midnight, got %v", time.Unix(day.Start, 0).In(location))
+	}
+	if day.Intervals != 2 || day.PeakAgents != 40 || day.AverageAgents != 35 || day.StaffedHours != 35 {
+		t.Errorf("day should have 2 intervals, peak 40, average 35 and 35 hours, got %+v", day)
+	}
+	// a full-time agent works 8 hours a day
+	if math.Abs(day.Fte-35.0/8) > 1e-9 {
+		t.Errorf("day should have %f FTE, got %f", 35.0/8, day.Fte)
+	}
+
+	params.Period = PeriodWeek
+	params.WeekStart = time.Monday
+	params.TimestampUnit = time.Millisecond
+	for i := range fte {
+		fte[i].Timestamp *= 1000
+	}
+	rollups, err = GetRollups(fte, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	week := rollups[1]
+	if len(rollups) != 2 || week.Start != time.Date(2024, 2, 26, 0, 0, 0, 0, location).Unix() {
+		t.Fatalf("should have a week of a and b starting on Monday, got %+v", rollups)
+	}
+	if week.StaffedHours != 50 || week.Fte != 50.0/40 || week.PeakAgents != 40 {
+		t.Errorf("week should have 50 hours, 1.25 FTE and peak 40, got %+v", week)
+	}
+
+	if _, err := GetRollups(fte, RollupParams{Period: PeriodWeek}); err == nil {
+		t.Error("missing interval length and contracted hours should fail")
+	}
+}
@@ -0,0 +1,253 @@
+// Package server exposes calculation of number of agents over HTTP with JSON
+// request and response bodies matching erlangc.FteParams and erlangc.FteResult.
+//
+// Endpoints:
+//
+//	POST /v1/fte  - array of FteParams in, array of FteResult out, in the same order
+//	GET  /healthz - 200 while the process is alive
+//	GET  /readyz  - 200 while the server accepts requests, 503 once it is shutting down
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+)
+
+const (
+	// DefaultMaxBodyBytes - maximum size of a request body
+	DefaultMaxBodyBytes = 10 << 20
+	// DefaultMaxRows - maximum number of rows of a request
+	DefaultMaxRows = 100000
+	// DefaultShutdownTimeout - time given to requests in flight on shutdown
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+// RowError - invalid or failed row of a request
+type RowError struct {
+	Row    int
+	ID     string
+	Index  int64
+	Errors []string
+}
+
+// ErrorResponse - body of all responses with a non 2xx status
+type ErrorResponse struct {
+	Error string
+	Rows  []RowError `json:",omitempty"`
+}
+
+// Server - HTTP handler of calculation requests
+type Server struct {
+	calculator      *erlangc.Calculator
+	maxBodyBytes    int64
+	maxRows         int
+	timeout         time.Duration
+	shutdownTimeout time.Duration
+	ready           atomic.Bool
+	mux             *http.ServeMux
+}
+
+// Option - configuration of a Server
+type Option func(*Server)
+
+// WithMaxBodyBytes sets maximum size of a request body, DefaultMaxBodyBytes when not set
+func WithMaxBodyBytes(n int64) Option {
+	return func(s *Server) {
+		s.maxBodyBytes = n
+	}
+}
+
+// WithMaxRows sets maximum number of rows of a request, DefaultMaxRows when not set
+func WithMaxRows(n int) Option {
+	return func(s *Server) {
+		s.maxRows = n
+	}
+}
+
+// WithTimeout sets maximum duration of a calculation, requests are not limited when not set
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.timeout = timeout
+	}
+}
+
+// WithShutdownTimeout sets time given to requests in flight on shutdown, DefaultShutdownTimeout when not set
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.shutdownTimeout = timeout
+	}
+}
+
+// New returns a server calculating with calculator, a calculator with default options when nil
+func New(calculator *erlangc.Calculator, options ...Option) *Server {
+	if calculator == nil {
+		calculator = erlangc.NewCalculator()
+	}
+	s := &Server{
+		calculator:      calculator,
+		maxBodyBytes:    DefaultMaxBodyBytes,
+		maxRows:         DefaultMaxRows,
+		shutdownTimeout: DefaultShutdownTimeout,
+		mux:             http.NewServeMux(),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	s.ready.Store(true)
+	s.mux.HandleFunc("/v1/fte", s.handleFte)
+	s.mux.HandleFunc("/healthz", s.handleHealth)
+	s.mux.HandleFunc("/readyz", s.handleReady)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe listens on addr and serves requests until ctx is done, then it shuts down gracefully
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve serves requests on listener until ctx is done. Readiness fails first, then requests
+// in flight are given the shutdown timeout to finish.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	httpServer := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	s.ready.Store(true)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		s.ready.Store(false)
+		return err
+	case <-ctx.Done():
+	}
+
+	s.ready.Store(false)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"Status": "ok"})
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if !s.ready.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"Status": "shutting down"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"Status": "ready"})
+}
+
+func (s *Server) handleFte(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+		return
+	}
+
+	var params []erlangc.FteParams
+	r.Body = http.MaxBytesReader(w, r.Body, s.maxBodyBytes)
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&params); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("body exceeds %d bytes", maxBytesErr.Limit), nil)
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid body: "+err.Error(), nil)
+		return
+	}
+	if len(params) > s.maxRows {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("%d rows exceed the limit of %d", len(params), s.maxRows), nil)
+		return
+	}
+
+	var rowErrs []RowError
+	for i, param := range params {
+		if err := s.calculator.Validate(param); err != nil {
+			rowErrs = append(rowErrs, getRowError(i, param, err))
+		}
+	}
+	if len(rowErrs) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("%d of %d rows are invalid", len(rowErrs), len(params)), rowErrs)
+		return
+	}
+
+	ctx := r.Context()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	fte, err := s.calculator.CalculateFteParallelContext(ctx, params)
+	if err != nil {
+		var unfinished *erlangc.UnfinishedError
+		if errors.As(err, &unfinished) {
+			writeError(w, http.StatusServiceUnavailable, unfinished.Error(), nil)
+			return
+		}
+		for _, err := range unwrapJoined(err) {
+			var rowErr *erlangc.RowError
+			if errors.As(err, &rowErr) {
+				rowErrs = append(rowErrs, getRowError(rowErr.Row, params[rowErr.Row], rowErr.Err))
+			}
+		}
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("%d of %d rows failed", len(rowErrs), len(params)), rowErrs)
+		return
+	}
+	writeJSON(w, http.StatusOK, fte)
+}
+
+func getRowError(row int, param erlangc.FteParams, err error) RowError {
+	rowErr := RowError{Row: row, ID: param.ID, Index: param.Index}
+	for _, err := range unwrapJoined(err) {
+		rowErr.Errors = append(rowErr.Errors, err.Error())
+	}
+	return rowErr
+}
+
+// unwrapJoined returns errors joined with errors.Join, or err itself
+func unwrapJoined(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+func writeError(w http.ResponseWriter, status int, message string, rows []RowError) {
+	writeJSON(w, status, ErrorResponse{Error: message, Rows: rows})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+)
+
+var params = []erlangc.FteParams{
+	{ID: "1", Index: 0, Volume: 500, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 20, Shrinkage: 0.3},
+	{ID: "1", Index: 1, Volume: 250, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 20, Shrinkage: 0.3},
+}
+
+func post(t *testing.T, handler http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/fte", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleFte(t *testing.T) {
+	s := New(nil)
+	body, _ := json.Marshal(params)
+	rec := post(t, s, string(body))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status should be 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var fte []erlangc.FteResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &fte); err != nil {
+		t.Fatal(err)
+	}
+	expected := erlangc.CalculateFte(params)
+	for i := range expected {
+		if fte[i] != expected[i] {
+			t.Errorf("row %d should be %+v, got %+v", i, expected[i], fte[i])
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/fte", nil)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET should be 405, got %d", rec.Code)
+	}
+}
+
+func TestHandleFteErrors(t *testing.T) {
+	invalid := append([]erlangc.FteParams{}, params...)
+	invalid[1].TargetServiceLevel = 80
+	body, _ := json.Marshal(invalid)
+	huge := append([]erlangc.FteParams{}, params...)
+	huge[0].Volume = 1e9
+	hugeBody, _ := json.Marshal(huge)
+	limited := erlangc.NewCalculator(erlangc.WithMaxIntensity(100))
+	validBody, _ := json.Marshal(params)
+
+	tests := []struct {
+		name   string
+		server *Server
+		body   string
+		status int
+		rows   int
+	}{
+		{"malformed", New(nil), "[{", http.StatusBadRequest, 0},
+		{"unknown field", New(nil), `[{"Volum": 1}]`, http.StatusBadRequest, 0},
+		{"invalid row", New(nil), string(body), http.StatusUnprocessableEntity, 1},
+		{"huge volume", New(nil), string(hugeBody), http.StatusUnprocessableEntity, 1},
+		{"intensity limit", New(limited), string(validBody), http.StatusUnprocessableEntity, 1},
+		{"body limit", New(nil, WithMaxBodyBytes(10)), string(body), http.StatusRequestEntityTooLarge, 0},
+		{"row limit", New(nil, WithMaxRows(1)), string(body), http.StatusRequestEntityTooLarge, 0},
+	}
+	for _, test := range tests {
+		rec := post(t, test.server, test.body)
+		if rec.Code != test.status {
+			t.Errorf("%s: status should be %d, got %d", test.name, test.status, rec.Code)
+		}
+		var res ErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if res.Error == "" || len(res.Rows) != test.rows {
+			t.Errorf("%s: response should have an error and %d rows, got %+v", test.name, test.rows, res)
+		}
+	}
+}
+
+func TestServeShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(ctx, listener)
+	}()
+
+	url := "http://" + listener.Addr().String()
+	for _, path := range []string{"/healthz", "/readyz"} {
+		res, err := http.Get(url + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("%s should be 200, got %d", path, res.StatusCode)
+		}
+	}
+	body, _ := json.Marshal(params)
+	res, err := http.Post(url+"/v1/fte", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("calculation should be 200, got %d", res.StatusCode)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("shutdown should not fail, got %v", err)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("readyz should be 503 after shutdown, got %d", rec.Code)
+	}
+}
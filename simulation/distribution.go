@@ -0,0 +1,41 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Distribution - random duration in seconds drawn with r
+type Distribution func(r *rand.Rand) float64
+
+// Exponential returns durations exponentially distributed around mean, the assumption of Erlang C and A
+func Exponential(mean float64) Distribution {
+	return func(r *rand.Rand) float64 {
+		return r.ExpFloat64() * mean
+	}
+}
+
+// Constant returns the same duration every time
+func Constant(value float64) Distribution {
+	return func(r *rand.Rand) float64 {
+		return value
+	}
+}
+
+// LogNormal returns log-normally distributed durations with mean and standard deviation,
+// handle times of real contact centers are usually closer to it than to the exponential distribution
+func LogNormal(mean float64, stddev float64) Distribution {
+	sigma2 := math.Log(1 + stddev*stddev/(mean*mean))
+	mu := math.Log(mean) - sigma2/2
+	sigma := math.Sqrt(sigma2)
+	return func(r *rand.Rand) float64 {
+		return math.Exp(mu + sigma*r.NormFloat64())
+	}
+}
+
+// Uniform returns durations uniformly distributed between min and max
+func Uniform(min float64, max float64) Distribution {
+	return func(r *rand.Rand) float64 {
+		return min + r.Float64()*(max-min)
+	}
+}
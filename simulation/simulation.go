@@ -0,0 +1,394 @@
+// Package simulation simulates a queue of a single interval event by event to check staffing
+// calculated with Erlang formulas, or to model cases they can't: handle times that aren't
+// exponential, impatient callers with any patience distribution or concurrent sessions that slow
+// agents down.
+//
+// Arrivals are a Poisson process. Every replication of the interval draws from its own random
+// source seeded with a mix of Seed and the replication number, so results only depend on the params.
+package simulation
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+)
+
+const (
+	// DefaultReplications - number of simulated intervals when Params.Replications is not set
+	DefaultReplications = 100
+	// warmUpHandleTimes - handle times of the default warm up, enough for a queue at 95% occupancy to settle
+	warmUpHandleTimes = 20
+	// warmUpSamples - number of handle times averaged for the default warm up
+	warmUpSamples = 1000
+)
+
+// Params - parameters of a simulated interval
+//
+// Volume - expected number of arrivals in the interval
+// IntervalLength - length of the interval in seconds
+// Agents - number of agents answering
+// Concurrency - number of sessions an agent handles at once, 1 when not set
+// ConcurrencyAhtInflation - share of the handle time added to a session per other session of its agent at its start
+// HandleTime - distribution of handle times
+// Patience - distribution of time arrivals wait before abandoning, nobody abandons when nil
+// TargetTime - target answer time in seconds
+// WarmUp - seconds simulated before the interval so it doesn't start with idle agents and an empty queue,
+// the longer of IntervalLength and 20 average handle times when 0, negative to start empty
+// Replications - number of simulated intervals, DefaultReplications when not set
+// Seed - seed the seeds of replications are derived from
+type Params struct {
+	Volume                  float64
+	IntervalLength          int64
+	Agents                  int64
+	Concurrency             int64
+	ConcurrencyAhtInflation float64
+	HandleTime              Distribution
+	Patience                Distribution
+	TargetTime              int64
+	WarmUp                  int64
+	Replications            int
+	Seed                    int64
+}
+
+// NewParams returns params simulating agents under the assumptions of the calculation of fteParams:
+// exponential handle times and, when Patience is set, exponential patience. Agents are the agents
+// answering, without shrinkage.
+func NewParams(fteParams erlangc.FteParams, agents int64) Params {
+	params := Params{
+		Volume:                  fteParams.Volume,
+		IntervalLength:          fteParams.IntervalLength,
+		Agents:                  agents,
+		Concurrency:             fteParams.Concurrency,
+		ConcurrencyAhtInflation: fteParams.ConcurrencyAhtInflation,
+		HandleTime:              Exponential(float64(fteParams.Aht)),
+		TargetTime:              fteParams.TargetTime,
+	}
+	if fteParams.Patience > 0 {
+		params.Patience = Exponential(float64(fteParams.Patience))
+	}
+	return params
+}
+
+// Validate checks that params are in their valid ranges, it returns all invalid fields as *erlangc.FieldError
+func (params Params) Validate() error {
+	var errs []error
+	check := func(valid bool, field string, value interface{}, reason string) {
+		if !valid {
+			errs = append(errs, &erlangc.FieldError{Field: field, Value: value, Reason: reason})
+		}
+	}
+
+	check(params.Volume >= 0 && !math.IsInf(params.Volume, 0), "Volume", params.Volume, "must be a non-negative number")
+	check(params.IntervalLength > 0, "IntervalLength", params.IntervalLength, "must be positive")
+	check(params.Agents > 0, "Agents", params.Agents, "must be positive")
+	check(params.Concurrency >= 0, "Concurrency", params.Concurrency, "must not be negative")
+	check(params.ConcurrencyAhtInflation >= 0, "ConcurrencyAhtInflation", params.ConcurrencyAhtInflation, "must not be negative")
+	check(params.HandleTime != nil, "HandleTime", nil, "must be set")
+	check(params.TargetTime >= 0, "TargetTime", params.TargetTime, "must not be negative")
+	check(params.Replications >= 0, "Replications", params.Replications, "must not be negative")
+
+	return errors.Join(errs...)
+}
+
+// Estimate - mean of a metric over the replications with its 95% confidence interval
+type Estimate struct {
+	Mean float64
+	Low  float64
+	High float64
+}
+
+// Result - metrics of the simulated interval
+//
+// Arrivals - number of arrivals in the interval
+// ServiceLevel - share of arrivals answered within the target time
+// WaitProbability - share of arrivals that had to wait
+// Asa - average speed of answer of the answered arrivals in seconds
+// Abandonment - share of arrivals that abandoned
+// Occupancy - share of time agents' sessions were busy during the interval
+type Result struct {
+	Replications    int
+	Arrivals        Estimate
+	ServiceLevel    Estimate
+	WaitProbability Estimate
+	Asa             Estimate
+	Abandonment     Estimate
+	Occupancy       Estimate
+}
+
+// replication - metrics of a single simulated interval
+type replication struct {
+	arrivals        float64
+	serviceLevel    float64
+	waitProbability float64
+	asa             float64
+	abandonment     float64
+	occupancy       float64
+}
+
+// Simulate simulates the interval Replications times in parallel and estimates its metrics,
+// it stops with ctx.Err() when ctx is done
+func Simulate(ctx context.Context, params Params) (Result, error) {
+	if err := params.Validate(); err != nil {
+		return Result{}, err
+	}
+	n := params.Replications
+	if n == 0 {
+		n = DefaultReplications
+	}
+	warmUp := float64(params.WarmUp)
+	if params.WarmUp == 0 {
+		r := rand.New(rand.NewSource(params.Seed))
+		mean := 0.0
+		for i := 0; i < warmUpSamples; i++ {
+			mean += params.HandleTime(r) / warmUpSamples
+		}
+		warmUp = math.Max(float64(params.IntervalLength), warmUpHandleTimes*mean)
+	} else if params.WarmUp < 0 {
+		warmUp = 0
+	}
+
+	replications := make([]replication, n)
+	next := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				replications[i] = simulate(params, warmUp, rand.New(rand.NewSource(getReplicationSeed(params.Seed, i))))
+			}
+		}()
+	}
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(next)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	metric := func(get func(replication) float64) Estimate {
+		values := make([]float64, n)
+		for i, r := range replications {
+			values[i] = get(r)
+		}
+		return getEstimate(values)
+	}
+	return Result{
+		Replications:    n,
+		Arrivals:        metric(func(r replication) float64 { return r.arrivals }),
+		ServiceLevel:    metric(func(r replication) float64 { return r.serviceLevel }),
+		WaitProbability: metric(func(r replication) float64 { return r.waitProbability }),
+		Asa:             metric(func(r replication) float64 { return r.asa }),
+		Abandonment:     metric(func(r replication) float64 { return r.abandonment }),
+		Occupancy:       metric(func(r replication) float64 { return r.occupancy }),
+	}, nil
+}
+
+// tQuantiles - 97.5% quantiles of Student's t distribution by degrees of freedom
+var tQuantiles = []float64{
+	math.Inf(1), 12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// getEstimate returns mean of values with its 95% confidence interval
+func getEstimate(values []float64) Estimate {
+	n := float64(len(values))
+	mean := 0.0
+	for _, v := range values {
+		mean += v / n
+	}
+	if len(values) < 2 {
+		return Estimate{Mean: mean, Low: math.Inf(-1), High: math.Inf(1)}
+	}
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean) / (n - 1)
+	}
+	t := 1.96
+	if len(values)-1 < len(tQuantiles) {
+		t = tQuantiles[len(values)-1]
+	}
+	half := t * math.Sqrt(variance/n)
+	return Estimate{Mean: mean, Low: mean - half, High: mean + half}
+}
+
+type eventKind int
+
+const (
+	eventArrival eventKind = iota
+	eventDeparture
+	eventAbandonment
+)
+
+type event struct {
+	time    float64
+	kind    eventKind
+	contact int
+	agent   int
+}
+
+// events - queue of events ordered by time
+type events []event
+
+func (e events) Len() int            { return len(e) }
+func (e events) Less(i, j int) bool  { return e[i].time < e[j].time }
+func (e events) Swap(i, j int)       { e[i], e[j] = e[j], e[i] }
+func (e *events) Push(x interface{}) { *e = append(*e, x.(event)) }
+func (e *events) Pop() interface{} {
+	old := *e
+	last := old[len(old)-1]
+	*e = old[:len(old)-1]
+	return last
+}
+
+type contact struct {
+	arrival  float64
+	answered bool
+	gone     bool
+}
+
+// getReplicationSeed mixes seed and replication i with splitmix64, so close seeds don't share
+// replications as they would with seed+i
+func getReplicationSeed(seed int64, i int) int64 {
+	z := uint64(seed) + uint64(i+1)*0x9E3779B97F4A7C15
+	z = (z ^ z>>30) * 0xBF58476D1CE4E5B9
+	z = (z ^ z>>27) * 0x94D049BB133111EB
+	return int64(z ^ z>>31)
+}
+
+// simulate simulates a single interval, it starts warmUp seconds before the interval and stops
+// when all arrivals of the interval are answered or abandoned
+func simulate(params Params, warmUp float64, r *rand.Rand) replication {
+	length := float64(params.IntervalLength)
+	concurrency := params.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	rate := params.Volume / length
+
+	sessions := make([]int64, params.Agents)
+	var contacts []contact
+	var waiting []int
+	queue := &events{}
+
+	var res replication
+	answered := 0.0
+	withinTarget := 0.0
+	waited := 0.0
+	abandoned := 0.0
+	totalWait := 0.0
+	busy := 0
+	busyTime := 0.0
+	now := -warmUp
+
+	scheduleArrival := func() {
+		if rate <= 0 {
+			return
+		}
+		if t := now + r.ExpFloat64()/rate; t < length {
+			heap.Push(queue, event{time: t, kind: eventArrival})
+		}
+	}
+	// answer assigns the contact to the agent with the fewest sessions, it returns false when all sessions are busy
+	answer := func(c int) bool {
+		agent := -1
+		for i, s := range sessions {
+			if s < concurrency && (agent < 0 || s < sessions[agent]) {
+				agent = i
+			}
+		}
+		if agent < 0 {
+			return false
+		}
+		handleTime := params.HandleTime(r) * (1 + params.ConcurrencyAhtInflation*float64(sessions[agent]))
+		sessions[agent]++
+		busy++
+		contacts[c].answered = true
+		heap.Push(queue, event{time: now + handleTime, kind: eventDeparture, contact: c, agent: agent})
+
+		if arrival := contacts[c].arrival; arrival >= 0 {
+			wait := now - arrival
+			answered++
+			totalWait += wait
+			if wait > 0 {
+				waited++
+			}
+			if wait <= float64(params.TargetTime) {
+				withinTarget++
+			}
+		}
+		return true
+	}
+
+	scheduleArrival()
+	for queue.Len() > 0 {
+		e := heap.Pop(queue).(event)
+		// busy sessions are counted during the interval only
+		busyTime += float64(busy) * math.Max(0, math.Min(e.time, length)-math.Max(now, 0))
+		now = e.time
+
+		switch e.kind {
+		case eventArrival:
+			c := len(contacts)
+			contacts = append(contacts, contact{arrival: now})
+			if now >= 0 {
+				res.arrivals++
+			}
+			if !answer(c) {
+				waiting = append(waiting, c)
+				if params.Patience != nil {
+					heap.Push(queue, event{time: now + params.Patience(r), kind: eventAbandonment, contact: c})
+				}
+			}
+			scheduleArrival()
+		case eventDeparture:
+			sessions[e.agent]--
+			busy--
+			for len(waiting) > 0 {
+				c := waiting[0]
+				waiting = waiting[1:]
+				if contacts[c].gone {
+					continue
+				}
+				answer(c)
+				break
+			}
+		case eventAbandonment:
+			if contacts[e.contact].answered {
+				continue
+			}
+			contacts[e.contact].gone = true
+			if contacts[e.contact].arrival >= 0 {
+				abandoned++
+			}
+		}
+		if now >= length && len(waiting) == 0 {
+			break
+		}
+	}
+
+	if res.arrivals > 0 {
+		res.serviceLevel = withinTarget / res.arrivals
+		res.waitProbability = (waited + abandoned) / res.arrivals
+		res.abandonment = abandoned / res.arrivals
+	} else {
+		res.serviceLevel = 1
+	}
+	if answered > 0 {
+		res.asa = totalWait / answered
+	}
+	res.occupancy = busyTime / (length * float64(params.Agents*concurrency))
+	return res
+}
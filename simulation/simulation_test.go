@@ -0,0 +1,112 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	erlangc "github.com/Tymeshift/erlang-c-go"
+)
+
+func within(estimate Estimate, value float64) bool {
+	return value >= estimate.Low && value <= estimate.High
+}
+
+func TestSimulateErlangC(t *testing.T) {
+	for _, fteParams := range []erlangc.FteParams{
+		{Volume: 500, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 20},
+		{Volume: 40, IntervalLength: 1800, Aht: 240, TargetServiceLevel: 0.8, TargetTime: 30},
+	} {
+		agents := erlangc.GetNumberOfAgents(fteParams).RawAgents
+		kpis := erlangc.GetKpis(erlangc.KpiParams{FteParams: fteParams, Agents: agents})
+		res, err := Simulate(context.Background(), NewParams(fteParams, agents))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !within(res.ServiceLevel, kpis.ServiceLevel) {
+			t.Errorf("Erlang C service level %f should be within %+v", kpis.ServiceLevel, res.ServiceLevel)
+		}
+		if !within(res.WaitProbability, kpis.WaitProbability) {
+			t.Errorf("Erlang C wait probability %f should be within %+v", kpis.WaitProbability, res.WaitProbability)
+		}
+		if !within(res.Asa, kpis.Asa) {
+			t.Errorf("Erlang C ASA %f should be within %+v", kpis.Asa, res.Asa)
+		}
+		if !within(res.Occupancy, kpis.Occupancy) {
+			t.Errorf("Erlang C occupancy %f should be within %+v", kpis.Occupancy, res.Occupancy)
+		}
+		if res.Abandonment.Mean != 0 {
+			t.Errorf("nobody should abandon without patience, got %+v", res.Abandonment)
+		}
+	}
+}
+
+func TestSimulateErlangA(t *testing.T) {
+	fteParams := erlangc.FteParams{Volume: 500, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 20, Patience: 60}
+	result := erlangc.GetNumberOfAgents(fteParams)
+	erlangA := erlangc.GetErlangA(result.Intensity, result.RawAgents, fteParams.TargetTime, fteParams.Aht, fteParams.Patience)
+	res, err := Simulate(context.Background(), NewParams(fteParams, result.RawAgents))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(res.ServiceLevel.Mean-erlangA.ServiceLevel) > 0.02 {
+		t.Errorf("service level should be close to Erlang A %f, got %+v", erlangA.ServiceLevel, res.ServiceLevel)
+	}
+	if math.Abs(res.Abandonment.Mean-erlangA.Abandonment) > 0.02 {
+		t.Errorf("abandonment should be close to Erlang A %f, got %+v", erlangA.Abandonment, res.Abandonment)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	params := Params{Volume: 100, IntervalLength: 900, Agents: 12, HandleTime: Exponential(100), TargetTime: 20, Replications: 200, Seed: 7}
+	first, err := Simulate(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := Simulate(context.Background(), params)
+	if first != second {
+		t.Errorf("same seed should give the same result, got %+v and %+v", first, second)
+	}
+	params.Seed = 8
+	if other, _ := Simulate(context.Background(), params); other == first {
+		t.Error("another seed should give another result")
+	}
+	seeds := map[int64]bool{}
+	for i := 0; i < params.Replications; i++ {
+		seeds[getReplicationSeed(7, i)] = true
+	}
+	for i := 0; i < params.Replications; i++ {
+		if seeds[getReplicationSeed(8, i)] {
+			t.Fatalf("replication %d of seed 8 should not share a seed with seed 7", i)
+		}
+	}
+
+	// constant handle times queue less than exponential ones of the same mean
+	params.Agents = 14
+	exponential, _ := Simulate(context.Background(), params)
+	params.HandleTime = Constant(100)
+	constant, _ := Simulate(context.Background(), params)
+	if constant.ServiceLevel.Low <= exponential.ServiceLevel.Mean {
+		t.Errorf("constant handle times should have higher service level than %+v, got %+v", exponential.ServiceLevel, constant.ServiceLevel)
+	}
+
+	// sessions slowed down by concurrency keep agents busier
+	params.HandleTime = LogNormal(100, 50)
+	params.Agents = 6
+	params.Concurrency = 2
+	fast, _ := Simulate(context.Background(), params)
+	params.ConcurrencyAhtInflation = 0.3
+	slow, _ := Simulate(context.Background(), params)
+	if slow.Occupancy.Mean <= fast.Occupancy.Mean {
+		t.Errorf("inflated handle times should raise occupancy above %f, got %f", fast.Occupancy.Mean, slow.Occupancy.Mean)
+	}
+
+	if _, err := Simulate(context.Background(), Params{IntervalLength: 900}); err == nil {
+		t.Error("missing agents and handle time should fail")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Simulate(ctx, params); err != context.Canceled {
+		t.Errorf("canceled context should fail with context.Canceled, got %v", err)
+	}
+}
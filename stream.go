@@ -0,0 +1,145 @@
+package erlangc
+
+import (
+	"context"
+	"io"
+)
+
+// StreamResult - result of a streamed row, Err is a *RowError when the strategy failed
+type StreamResult struct {
+	Result FteResult
+	Err    error
+}
+
+type streamJob struct {
+	row    int
+	params FteParams
+	result chan StreamResult
+}
+
+// CalculateFteStream calculates number of agents for params received from in with at most as many goroutines
+// as the calculator's WithConcurrency (runtime.GOMAXPROCS for package functions) and sends results to
+// the returned channel in the order of in, results of invalid params carry a *RowError.
+// At most that many rows are in flight, so a slow reader of the results slows down reading from in.
+// The returned channel is closed when in is closed and all results are sent, or when ctx is done.
+func CalculateFteStream(ctx context.Context, in <-chan FteParams) <-chan StreamResult {
+	return defaultCalculator.CalculateFteStream(ctx, in)
+}
+
+func (c *Calculator) CalculateFteStream(ctx context.Context, in <-chan FteParams) <-chan StreamResult {
+	limit := c.getConcurrency()
+	out := make(chan StreamResult)
+	jobs := make(chan streamJob)
+	// results are collected in the order rows were read, the buffer size bounds rows in flight
+	pending := make(chan chan StreamResult, limit)
+
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for row := 0; ; row++ {
+			var params FteParams
+			var ok bool
+			select {
+			case params, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+			job := streamJob{row: row, params: params, result: make(chan StreamResult, 1)}
+			select {
+			case pending <- job.result:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for w := 0; w < limit; w++ {
+		go func() {
+			for job := range jobs {
+				result, err := c.runChecked(ctx, job.params)
+				if err != nil {
+					result = getEmptyResult(job.params)
+					err = &RowError{Row: job.row, ID: job.params.ID, Index: job.params.Index, Err: err}
+				}
+				job.result <- StreamResult{Result: result, Err: err}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(out)
+		for slot := range pending {
+			var result StreamResult
+			select {
+			case result = <-slot:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case out <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// CalculateFteEach reads params from next until it returns io.EOF, calculates them like CalculateFteStream
+// and passes results to emit in the same order. It stops on the first error of next, emit or a row,
+// or when ctx is done. next is called from a separate goroutine.
+func CalculateFteEach(ctx context.Context, next func() (FteParams, error), emit func(FteResult) error) error {
+	return defaultCalculator.CalculateFteEach(ctx, next, emit)
+}
+
+func (c *Calculator) CalculateFteEach(ctx context.Context, next func() (FteParams, error), emit func(FteResult) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	in := make(chan FteParams)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(in)
+		for {
+			params, err := next()
+			if err != nil {
+				if err != io.EOF {
+					readErr <- err
+				}
+				return
+			}
+			select {
+			case in <- params:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for result := range c.CalculateFteStream(ctx, in) {
+		if result.Err != nil {
+			return result.Err
+		}
+		if err := emit(result.Result); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case err := <-readErr:
+		return err
+	default:
+		return nil
+	}
+}
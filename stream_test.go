@@ -0,0 +1,101 @@
+package erlangc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestCalculateFteStream(t *testing.T) {
+	params := readFteParams(t)
+	for i := range params {
+		params[i].Engine = EngineFloat
+	}
+	expected := CalculateFte(params)
+
+	in := make(chan FteParams)
+	go func() {
+		for _, param := range params {
+			in <- param
+		}
+		close(in)
+	}()
+
+	i := 0
+	for res := range NewCalculator(WithConcurrency(4)).CalculateFteStream(context.Background(), in) {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		if res.Result != expected[i] {
+			t.Fatalf("row %d should be %+v, got %+v", i, expected[i], res.Result)
+		}
+		i++
+	}
+	if i != len(params) {
+		t.Errorf("stream should return %d rows, got %d", len(params), i)
+	}
+}
+
+func TestCalculateFteEach(t *testing.T) {
+	params := readFteParams(t)[:100]
+	var ndjson bytes.Buffer
+	enc := json.NewEncoder(&ndjson)
+	for i := range params {
+		params[i].Engine = EngineFloat
+		enc.Encode(params[i])
+	}
+	expected := CalculateFte(params)
+
+	dec := json.NewDecoder(&ndjson)
+	var out bytes.Buffer
+	enc = json.NewEncoder(&out)
+	err := CalculateFteEach(context.Background(), func() (FteParams, error) {
+		var param FteParams
+		err := dec.Decode(&param)
+		return param, err
+	}, func(res FteResult) error {
+		return enc.Encode(res)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dec = json.NewDecoder(&out)
+	for i := range expected {
+		var res FteResult
+		if err := dec.Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		if res != expected[i] {
+			t.Fatalf("row %d should be %+v, got %+v", i, expected[i], res)
+		}
+	}
+
+	stop := errors.New("stop")
+	emitted := 0
+	calculator := NewCalculator(WithConcurrency(2))
+	err = calculator.CalculateFteEach(context.Background(), func() (FteParams, error) {
+		return params[0], nil
+	}, func(res FteResult) error {
+		emitted++
+		if emitted == 10 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || emitted != 10 {
+		t.Errorf("emit error should stop the stream after 10 rows, got %v after %d", err, emitted)
+	}
+
+	err = calculator.CalculateFteEach(context.Background(), func() (FteParams, error) {
+		return FteParams{}, io.ErrUnexpectedEOF
+	}, func(res FteResult) error {
+		return nil
+	})
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("read error should be returned, got %v", err)
+	}
+}
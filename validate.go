@@ -0,0 +1,126 @@
+package erlangc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// MinMaxOccupancy - lowest MaxOccupancy other than 0 accepted by Validate
+	MinMaxOccupancy = 0.1
+	// DefaultMaxIntensity - highest traffic intensity in erlangs accepted by Validate
+	DefaultMaxIntensity = 10000.0
+	// DefaultMaxAgents - highest MinStaffing accepted by Validate
+	DefaultMaxAgents = 50000
+	// MaxConcurrency - highest Concurrency accepted by Validate
+	MaxConcurrency = 100
+)
+
+// FieldError - invalid value of a FteParams field
+type FieldError struct {
+	Field  string
+	Value  interface{}
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid %s %v: %s", e.Field, e.Value, e.Reason)
+}
+
+// RowError - error of a single row of a batch
+type RowError struct {
+	Row   int
+	ID    string
+	Index int64
+	Err   error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d (id %s, index %d): %s", e.Row, e.ID, e.Index, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks that parameters are in their valid ranges, it returns all invalid fields as *FieldError,
+// traffic intensity is limited to DefaultMaxIntensity and MinStaffing to DefaultMaxAgents
+func (fteParams FteParams) Validate() error {
+	return fteParams.validate(DefaultMaxIntensity, DefaultMaxAgents)
+}
+
+// Validate checks parameters like FteParams.Validate with the intensity and agents limits of the calculator
+func (c *Calculator) Validate(fteParams FteParams) error {
+	return fteParams.validate(c.getMaxIntensity(), c.getMaxAgents())
+}
+
+func (fteParams FteParams) validate(maxIntensity float64, maxAgents int64) error {
+	var errs []error
+	check := func(valid bool, field string, value interface{}, reason string) {
+		if !valid {
+			errs = append(errs, &FieldError{Field: field, Value: value, Reason: reason})
+		}
+	}
+
+	check(fteParams.Volume >= 0 && !math.IsInf(fteParams.Volume, 0), "Volume", fteParams.Volume, "must be a non-negative number")
+	check(fteParams.IntervalLength > 0, "IntervalLength", fteParams.IntervalLength, "must be positive")
+	check(fteParams.Aht >= 0, "Aht", fteParams.Aht, "must not be negative")
+	check(fteParams.Aht > 0 || fteParams.Volume == 0, "Aht", fteParams.Aht, "must be positive when there is volume")
+	check(fteParams.TargetServiceLevel >= 0 && fteParams.TargetServiceLevel < 1, "TargetServiceLevel", fteParams.TargetServiceLevel, "must be in [0, 1)")
+	check(fteParams.TargetTime >= 0, "TargetTime", fteParams.TargetTime, "must not be negative")
+	check(fteParams.TargetAsa >= 0, "TargetAsa", fteParams.TargetAsa, "must not be negative")
+	check(fteParams.MaxOccupancy >= 0 && fteParams.MaxOccupancy <= 1, "MaxOccupancy", fteParams.MaxOccupancy, "must be in [0, 1]")
+	check(fteParams.MaxOccupancy == 0 || fteParams.MaxOccupancy >= MinMaxOccupancy, "MaxOccupancy", fteParams.MaxOccupancy, fmt.Sprintf("must be 0 or at least %g", MinMaxOccupancy))
+	check(fteParams.Shrinkage >= 0 && fteParams.Shrinkage < 1, "Shrinkage", fteParams.Shrinkage, "must be in [0, 1)")
+	check(fteParams.MinStaffing >= 0, "MinStaffing", fteParams.MinStaffing, "must not be negative")
+	check(fteParams.MinStaffing <= maxAgents, "MinStaffing", fteParams.MinStaffing, fmt.Sprintf("must not exceed %d", maxAgents))
+	check(fteParams.Concurrency >= 0, "Concurrency", fteParams.Concurrency, "must not be negative")
+	check(fteParams.Concurrency <= MaxConcurrency, "Concurrency", fteParams.Concurrency, fmt.Sprintf("must not exceed %d", MaxConcurrency))
+	check(fteParams.ConcurrencyAhtInflation >= 0, "ConcurrencyAhtInflation", fteParams.ConcurrencyAhtInflation, "must not be negative")
+	check(fteParams.Patience >= 0, "Patience", fteParams.Patience, "must not be negative")
+	check(fteParams.Engine >= EngineDefault && fteParams.Engine <= EngineApprox, "Engine", fteParams.Engine, "unknown engine")
+	if fteParams.IntervalLength > 0 {
+		aht := getConcurrentAht(fteParams.Aht, fteParams.Concurrency, fteParams.ConcurrencyAhtInflation)
+		intensity := getIntensity(fteParams.Volume, aht, fteParams.IntervalLength)
+		check(!(intensity > maxIntensity), "Volume", fteParams.Volume, fmt.Sprintf("traffic intensity %g erlangs exceeds %g", intensity, maxIntensity))
+	}
+
+	return errors.Join(errs...)
+}
+
+// GetNumberOfAgentsChecked validates parameters before calculating number of agents
+func GetNumberOfAgentsChecked(fteParams FteParams) (FteResult, error) {
+	return defaultCalculator.GetNumberOfAgentsChecked(fteParams)
+}
+
+func (c *Calculator) GetNumberOfAgentsChecked(fteParams FteParams) (FteResult, error) {
+	if err := c.Validate(fteParams); err != nil {
+		return getEmptyResult(fteParams), err
+	}
+	return c.GetNumberOfAgents(fteParams), nil
+}
+
+// CalculateFteChecked calculates number of agents like CalculateFte, rows with invalid parameters
+// or failing strategies are reported as *RowError and have only ID, Index and Timestamp set in the result
+func CalculateFteChecked(params []FteParams) ([]FteResult, error) {
+	return defaultCalculator.CalculateFteChecked(params)
+}
+
+func (c *Calculator) CalculateFteChecked(params []FteParams) ([]FteResult, error) {
+	ctx := context.Background()
+	fte := make([]FteResult, len(params))
+	finished := make([]bool, len(params))
+	errs := make([]error, len(params))
+	for i, param := range params {
+		fte[i] = getEmptyResult(param)
+		finished[i] = true
+		var result FteResult
+		if result, errs[i] = c.runChecked(ctx, param); errs[i] == nil {
+			fte[i] = result
+		}
+	}
+
+	return fte, c.getBatchError(ctx, params, finished, errs)
+}
@@ -0,0 +1,131 @@
+package erlangc
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Index:              0,
+		Volume:             10,
+		IntervalLength:     900,
+		MaxOccupancy:       0.8,
+		Shrinkage:          0.2,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+	}
+	if err := params.Validate(); err != nil {
+		t.Errorf("params should be valid, got %s", err)
+	}
+
+	params.Volume = 0
+	params.Aht = 0
+	if err := params.Validate(); err != nil {
+		t.Errorf("params without volume and aht should be valid, got %s", err)
+	}
+
+	params.Volume = math.NaN()
+	params.Aht = 300
+	params.IntervalLength = 0
+	params.TargetServiceLevel = 1
+	params.Shrinkage = 1
+	err := params.Validate()
+	fields := map[string]bool{}
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var fieldErr *FieldError
+		if !errors.As(e, &fieldErr) {
+			t.Fatalf("error should be a field error, got %s", e)
+		}
+		fields[fieldErr.Field] = true
+	}
+	for _, field := range []string{"Volume", "IntervalLength", "TargetServiceLevel", "Shrinkage"} {
+		if !fields[field] {
+			t.Errorf("%s should be invalid, got %s", field, err)
+		}
+	}
+	if fields["Aht"] {
+		t.Errorf("Aht should be valid, got %s", err)
+	}
+}
+
+func TestValidateLimits(t *testing.T) {
+	params := FteParams{
+		ID:                 "1",
+		Volume:             10,
+		IntervalLength:     900,
+		MaxOccupancy:       1e-12,
+		Aht:                300,
+		TargetServiceLevel: 0.8,
+		TargetTime:         60,
+	}
+	var fieldErr *FieldError
+	if err := params.Validate(); !errors.As(err, &fieldErr) || fieldErr.Field != "MaxOccupancy" {
+		t.Errorf("tiny MaxOccupancy should be invalid, got %v", err)
+	}
+
+	params.MaxOccupancy = 0
+	params.Volume = 1e9
+	if err := params.Validate(); !errors.As(err, &fieldErr) || fieldErr.Field != "Volume" {
+		t.Errorf("huge Volume should be invalid, got %v", err)
+	}
+
+	params.Volume = 3000
+	if err := params.Validate(); err != nil {
+		t.Errorf("1000 erlangs should be valid, got %s", err)
+	}
+	calculator := NewCalculator(WithMaxIntensity(500))
+	if err := calculator.Validate(params); !errors.As(err, &fieldErr) || fieldErr.Field != "Volume" {
+		t.Errorf("1000 erlangs should exceed calculator limit, got %v", err)
+	}
+
+	params.MinStaffing = 300000
+	params.Concurrency = 1000
+	err := params.Validate()
+	for _, field := range []string{"MinStaffing", "Concurrency"} {
+		found := false
+		for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+			found = found || errors.As(e, &fieldErr) && fieldErr.Field == field
+		}
+		if !found {
+			t.Errorf("%s should be invalid, got %v", field, err)
+		}
+	}
+	params.Concurrency = 0
+	if err := NewCalculator(WithMaxAgents(400000)).Validate(params); err != nil {
+		t.Errorf("MinStaffing should be under calculator limit, got %s", err)
+	}
+
+	params.MinStaffing = 0
+	res, err := calculator.GetNumberOfAgentsChecked(params)
+	if err == nil || res != getEmptyResult(params) {
+		t.Errorf("invalid params should have empty result with ID, got %+v, %v", res, err)
+	}
+}
+
+func TestCalculateFteChecked(t *testing.T) {
+	params := []FteParams{
+		{ID: "1", Index: 0, Volume: 10, IntervalLength: 900, Aht: 300, TargetServiceLevel: 0.8, TargetTime: 60},
+		{ID: "1", Index: 1, Volume: 10, IntervalLength: 900, Aht: 0, TargetServiceLevel: 0.8, TargetTime: 60},
+	}
+	fte, err := CalculateFteChecked(params)
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || rowErr.Row != 1 {
+		t.Fatalf("second row should be invalid, got %v", err)
+	}
+	var fieldErr *FieldError
+	if !errors.As(rowErr, &fieldErr) || fieldErr.Field != "Aht" {
+		t.Errorf("Aht should be invalid, got %s", rowErr)
+	}
+	if fte[0].Volume != GetNumberOfAgents(params[0]).Volume || fte[1].Volume != 0 || fte[1].Index != 1 {
+		t.Errorf("only valid rows should be calculated, got %+v", fte)
+	}
+
+	_, err = GetNumberOfAgentsChecked(params[1])
+	if !errors.As(err, &fieldErr) {
+		t.Errorf("GetNumberOfAgentsChecked should return field error, got %v", err)
+	}
+}